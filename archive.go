@@ -0,0 +1,337 @@
+package gotumblr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//The name of the manifest file of an archive.
+const ArchiveManifestFile = "manifest.json"
+
+//The sources of the posts of an archive. The posts of each are written to <source>.jsonl, newest first.
+//posts and likes are archived incrementally; queue, drafts and submissions are archived anew every time,
+//since their posts come and go.
+var ArchiveSources = []string{"posts", "queue", "drafts", "submissions", "likes"}
+
+//Describes an archive written by ExportBlog.
+type ArchiveManifest struct {
+	//The blog the archive is of.
+	Blog string `json:"blog"`
+	//When the archive was last written.
+	Updated time.Time `json:"updated"`
+	//The number of posts of each source.
+	Posts map[string]int `json:"posts"`
+	//The path of the downloaded media, relative to the archive, by url.
+	Media map[string]string `json:"media"`
+	//The width of the downloaded photos (see ExportOptions.PhotoWidth).
+	PhotoWidth int64 `json:"photo_width,omitempty"`
+	//The media that couldn't be downloaded, with the error. They are tried again by the next export.
+	FailedMedia map[string]string `json:"failed_media,omitempty"`
+	//The error of downloading the avatar of the blog, if it couldn't be downloaded. It is tried again by the next export.
+	FailedAvatar string `json:"failed_avatar,omitempty"`
+	//Every file of the archive but the manifest, by path relative to the archive.
+	Files map[string]ArchiveFile `json:"files"`
+}
+
+//A file of an archive.
+type ArchiveFile struct {
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+//A line of the JSON Lines files of posts of an archive.
+type ArchivedPost struct {
+	//The post as the API returned it.
+	Raw json.RawMessage `json:"raw"`
+	//The post parsed by ParsePost, encoded again.
+	Typed json.RawMessage `json:"typed"`
+}
+
+//Returns the post parsed into its type of post. See ParsePost.
+func (p ArchivedPost) Post() (Post, error) {
+	return ParsePost(p.Raw)
+}
+
+//Options of ExportBlog.
+type ExportOptions struct {
+	//Don't download the media of the posts and the avatar of the blog.
+	SkipMedia bool
+	//The width of the photos to download (see DownloadOptions). The largest size if zero.
+	PhotoWidth int64
+	//The number of media files downloaded at the same time. DefaultDownloadConcurrency if zero.
+	Concurrency int
+}
+
+//Archives the blog in the directory dir, which is created if needed: its info, avatar, posts
+//(published, queued, drafts, submissions and likes) and the media of its posts, along with a manifest.
+//When dir already has an archive of the blog, only the posts newer than the archived ones are fetched,
+//and media that is already archived isn't downloaded again.
+func (trc *TumblrRestClient) ExportBlog(ctx context.Context, blogname, dir string, options ExportOptions) (*ArchiveManifest, error) {
+	manifest, err := ReadArchiveManifest(dir)
+	if os.IsNotExist(err) {
+		manifest = &ArchiveManifest{Blog: NormalizeBlogIdentifier(blogname)}
+	} else if err != nil {
+		return nil, err
+	}
+	if NormalizeBlogIdentifier(manifest.Blog) != NormalizeBlogIdentifier(blogname) {
+		return nil, fmt.Errorf("gotumblr: %s has an archive of %s, not %s", dir, manifest.Blog, blogname)
+	}
+	manifest.Posts = map[string]int{}
+	if manifest.Media == nil {
+		manifest.Media = map[string]string{}
+	}
+	manifest.FailedMedia = map[string]string{}
+	manifest.FailedAvatar = ""
+	if err := os.MkdirAll(filepath.Join(dir, "media"), 0755); err != nil {
+		return nil, err
+	}
+
+	data := trc.request.GetContext(ctx, blogPath(blogname, "/info"), map[string]string{"api_key": trc.request.apiKey})
+	if data.Meta.Status != 200 {
+		return nil, fmt.Errorf("gotumblr: exporting %s: %s", blogname, data.Meta.Msg)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "blog.json"), data.Response, 0644); err != nil {
+		return nil, err
+	}
+	if !options.SkipMedia {
+		if err := trc.exportAvatar(ctx, blogname, dir); ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			manifest.FailedAvatar = err.Error()
+		}
+	}
+
+	iterators := map[string]*PostIterator{
+		"posts":       trc.PostsIterator(ctx, blogname, "", map[string]string{}),
+		"queue":       trc.QueueIterator(ctx, blogname),
+		"drafts":      trc.DraftsIterator(ctx, blogname),
+		"submissions": trc.SubmissionsIterator(ctx, blogname),
+		"likes":       trc.BlogLikesIterator(ctx, blogname),
+	}
+	posts := []Post{}
+	for _, source := range ArchiveSources {
+		incremental := source == "posts" || source == "likes"
+		records, err := exportPosts(filepath.Join(dir, source+".jsonl"), iterators[source], incremental)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Posts[source] = len(records)
+		for _, record := range records {
+			post, err := record.Post()
+			if err != nil {
+				return nil, err
+			}
+			posts = append(posts, post)
+		}
+	}
+	if !options.SkipMedia {
+		if err := trc.exportMedia(ctx, dir, manifest, posts, options); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest.Files, err = archiveFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Updated = time.Now().UTC()
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return manifest, ioutil.WriteFile(filepath.Join(dir, ArchiveManifestFile), content, 0644)
+}
+
+//Downloads the media of the posts to the media directory of the archive,
+//recording it and the media that couldn't be downloaded in the manifest.
+func (trc *TumblrRestClient) exportMedia(ctx context.Context, dir string, manifest *ArchiveManifest, posts []Post, options ExportOptions) error {
+	downloaded := map[string]string{}
+	for mediaUrl, local := range manifest.Media {
+		downloaded[mediaUrl] = strings.TrimPrefix(local, "media/")
+	}
+	files, err := trc.DownloadMedia(ctx, filepath.Join(dir, "media"), posts, DownloadOptions{
+		PhotoWidth:  options.PhotoWidth,
+		Concurrency: options.Concurrency,
+		Downloaded:  downloaded,
+	})
+	if err != nil {
+		return err
+	}
+	manifest.PhotoWidth = options.PhotoWidth
+	for _, file := range files {
+		if file.Error != "" {
+			manifest.FailedMedia[file.Url] = file.Error
+		} else if file.Path != "" {
+			manifest.Media[file.Url] = "media/" + file.Path
+		}
+	}
+	return nil
+}
+
+//Downloads the largest avatar of the blog to avatar.<extension>.
+func (trc *TumblrRestClient) exportAvatar(ctx context.Context, blogname, dir string) error {
+	avatar, err := trc.AvatarImage(ctx, blogname, 512)
+	if err != nil {
+		return err
+	}
+	content, err := avatar.Bytes()
+	if err != nil {
+		return err
+	}
+	extension := ".png"
+	if parsed, err := url.Parse(avatar.Url); err == nil && path.Ext(parsed.Path) != "" {
+		extension = path.Ext(parsed.Path)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "avatar"+extension), content, 0644)
+}
+
+//Writes the posts of the iterator to the file at path, newest first, and returns all posts in the file.
+//If incremental is true, the posts already in the file are kept and skipped, and the iteration stops
+//at the first of them that isn't pinned, since pinned posts come first whatever their age.
+func exportPosts(path string, it *PostIterator, incremental bool) ([]ArchivedPost, error) {
+	archived := map[int64]bool{}
+	var old []ArchivedPost
+	if incremental {
+		var err error
+		old, err = readArchivedPosts(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, record := range old {
+			var base BasePost
+			json.Unmarshal(record.Raw, &base)
+			archived[base.Id] = true
+		}
+	}
+	records := []ArchivedPost{}
+	for it.Next() {
+		post, err := ParsePost(it.Post())
+		if err != nil {
+			return nil, err
+		}
+		if archived[post.Base().Id] {
+			if post.Base().Is_pinned {
+				continue
+			}
+			break
+		}
+		typed, err := json.Marshal(post)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, ArchivedPost{Raw: it.Post(), Typed: typed})
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	records = append(records, old...)
+	var content bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		content.Write(line)
+		content.WriteByte('\n')
+	}
+	return records, ioutil.WriteFile(path, content.Bytes(), 0644)
+}
+
+//Reads the manifest of the archive in dir.
+func ReadArchiveManifest(dir string) (*ArchiveManifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ArchiveManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ArchiveManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+//Reads the posts of a source (e.g. posts or drafts) of the archive in dir, newest first.
+//It returns no posts if the archive has no posts from the source.
+func ReadArchivePosts(dir, source string) ([]ArchivedPost, error) {
+	posts, err := readArchivedPosts(filepath.Join(dir, source+".jsonl"))
+	if os.IsNotExist(err) {
+		return []ArchivedPost{}, nil
+	}
+	return posts, err
+}
+
+func readArchivedPosts(path string) ([]ArchivedPost, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	posts := []ArchivedPost{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		var post ArchivedPost
+		if err := json.Unmarshal(scanner.Bytes(), &post); err != nil {
+			return nil, fmt.Errorf("gotumblr: reading %s: %v", path, err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, scanner.Err()
+}
+
+//Checks that the files of the archive in dir match the sizes and checksums of its manifest.
+func VerifyArchive(dir string) error {
+	manifest, err := ReadArchiveManifest(dir)
+	if err != nil {
+		return err
+	}
+	files, err := archiveFiles(dir)
+	if err != nil {
+		return err
+	}
+	problems := []string{}
+	for name, want := range manifest.Files {
+		if got, ok := files[name]; !ok {
+			problems = append(problems, name+" is missing")
+		} else if got != want {
+			problems = append(problems, name+" has changed")
+		}
+	}
+	sort.Strings(problems)
+	if len(problems) != 0 {
+		return fmt.Errorf("gotumblr: verifying the archive in %s: %s", dir, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+//Returns the size and the checksum of every file in dir but the manifest, by path relative to dir.
+func archiveFiles(dir string) (map[string]ArchiveFile, error) {
+	files := map[string]ArchiveFile{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == ArchiveManifestFile {
+			return err
+		}
+		size, sum, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = ArchiveFile{Size: size, Sha256: sum}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,272 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Options of ImportArchive.
+type ImportOptions struct {
+	//The sources of the archive to import (see ArchiveSources). posts, queue and drafts if empty.
+	Sources []string
+	//The file that maps the ids of the archived posts to the ids of the imported ones.
+	//import_<blog>.json in the archive if empty, with the blog identifier normalized.
+	MapFile string
+}
+
+//Re-creates the posts of the archive in dir, written by ExportBlog, on the blog.
+//The posts keep their type, dates, tags, slugs, formats and states; queued posts keep their place in the queue
+//and drafts stay drafts. Archived media is uploaded again and media that isn't archived is referenced by its url.
+//Answers can't be created through the API, so they are imported as text posts.
+//
+//Posts that can't be re-created, such as photo posts whose photos are neither archived nor linked,
+//are mapped to an empty id and skipped.
+//
+//The id of every imported post is written to the map file as soon as it is created and the posts
+//that are in it already are skipped, so an import that failed can be run again to resume it.
+//It returns the map of archived post ids to imported post ids.
+func (trc *TumblrRestClient) ImportArchive(ctx context.Context, blogname, dir string, options ImportOptions) (map[string]string, error) {
+	manifest, err := ReadArchiveManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	mapFile := options.MapFile
+	if mapFile == "" {
+		mapFile = filepath.Join(dir, "import_"+NormalizeBlogIdentifier(blogname)+".json")
+	}
+	ids := map[string]string{}
+	if content, err := ioutil.ReadFile(mapFile); err == nil {
+		if err := json.Unmarshal(content, &ids); err != nil {
+			return nil, fmt.Errorf("gotumblr: reading %s: %v", mapFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	sources := options.Sources
+	if len(sources) == 0 {
+		sources = []string{"posts", "queue", "drafts"}
+	}
+	for _, source := range sources {
+		records, err := ReadArchivePosts(dir, source)
+		if err != nil {
+			return nil, err
+		}
+		//The queue is archived in the order it is published in and the other sources newest first.
+		if source != "queue" {
+			for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+				records[i], records[j] = records[j], records[i]
+			}
+		}
+		for _, record := range records {
+			if err := ctx.Err(); err != nil {
+				return ids, err
+			}
+			post, err := record.Post()
+			if err != nil {
+				return ids, err
+			}
+			oldId := strconv.FormatInt(post.Base().Id, 10)
+			if _, ok := ids[oldId]; ok {
+				continue
+			}
+			var queued QueuedPost
+			json.Unmarshal(record.Raw, &queued)
+			postType, params := importParams(post, queued.PublishTime())
+			files, err := importMedia(dir, manifest, post, params)
+			if err != nil {
+				return ids, err
+			}
+			result, err := trc.createPost(ctx, blogname, postType, params, files)
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				result.ID = ""
+			} else if err != nil {
+				return ids, fmt.Errorf("gotumblr: importing post %s: %v", oldId, err)
+			}
+			ids[oldId] = result.ID
+			if err := writeImportMap(mapFile, ids); err != nil {
+				return ids, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+//Creates a post of the given type, uploading the files if there are any.
+func (trc *TumblrRestClient) createPost(ctx context.Context, blogname, postType string, options map[string]string, files []UploadFile) (PostResult, error) {
+	checked := map[string]string{}
+	for key, value := range options {
+		checked[key] = value
+	}
+	for _, file := range files {
+		checked[file.Field] = file.Name
+	}
+	if err := ValidatePost(postType, checked); err != nil {
+		return PostResult{}, err
+	}
+	options["type"] = postType
+	requestUrl := blogPath(blogname, "/post")
+	var data CompleteResponse
+	if len(files) == 0 {
+		data = trc.request.PostContext(ctx, requestUrl, options)
+	} else {
+		data = trc.request.PostMultipart(ctx, requestUrl, options, files)
+	}
+	return postResult(data, 201)
+}
+
+//Returns the type and the options of the Create* call that re-creates the post,
+//without the media that importMedia adds.
+func importParams(post Post, publishOn time.Time) (string, map[string]string) {
+	base := post.Base()
+	params := map[string]string{}
+	if len(base.Tags) != 0 {
+		params["tags"] = strings.Join(base.Tags, ",")
+	}
+	if base.Date != "" {
+		params["date"] = base.Date
+	}
+	if base.Slug != "" && validSlug(base.Slug) {
+		params["slug"] = base.Slug
+	}
+	if base.Format != "" {
+		params["format"] = base.Format
+	}
+	switch base.State {
+	case "queued":
+		params["state"] = "queue"
+		if !publishOn.IsZero() {
+			params["publish_on"] = publishOn.UTC().Format(time.RFC3339)
+		}
+	case "draft", "private":
+		params["state"] = base.State
+	case "submission":
+		params["state"] = "draft"
+	default:
+		params["state"] = "published"
+	}
+	setNonEmpty := func(key, value string) {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	switch post := post.(type) {
+	case TextPost:
+		setNonEmpty("title", post.Title)
+		params["body"] = post.Body
+		return "text", params
+	case PhotoPost:
+		setNonEmpty("caption", post.Caption)
+		return "photo", params
+	case QuotePost:
+		params["quote"] = post.Text
+		setNonEmpty("source", post.Source)
+		return "quote", params
+	case LinkPost:
+		params["url"] = post.Url
+		setNonEmpty("title", post.Title)
+		setNonEmpty("description", post.Description)
+		return "link", params
+	case ChatPost:
+		setNonEmpty("title", post.Title)
+		params["conversation"] = post.Body
+		return "chat", params
+	case AudioPost:
+		setNonEmpty("caption", post.Caption)
+		return "audio", params
+	case VideoPost:
+		setNonEmpty("caption", post.Caption)
+		return "video", params
+	case AnswerPost:
+		asker := post.Asking_name
+		if asker == "" {
+			asker = "Anonymous"
+		}
+		params["title"] = asker + " asked: " + post.Question
+		params["body"] = post.Answer
+		return "text", params
+	}
+	params["body"] = ""
+	return "text", params
+}
+
+//Adds the media of the post to the options of the call that re-creates it: the files archived in dir
+//are returned to be uploaded and the urls of media that isn't archived are added to the options.
+func importMedia(dir string, manifest *ArchiveManifest, post Post, params map[string]string) ([]UploadFile, error) {
+	media := postMedia(post, manifest.PhotoWidth)
+	local := func(mediaUrl string) (UploadFile, bool, error) {
+		name, ok := manifest.Media[mediaUrl]
+		if !ok {
+			return UploadFile{}, false, nil
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			return UploadFile{}, false, nil
+		}
+		return UploadFile{Name: path.Base(name), Content: content}, err == nil, err
+	}
+	switch post := post.(type) {
+	case PhotoPost:
+		files := []UploadFile{}
+		for i, mediaUrl := range media {
+			file, ok, err := local(mediaUrl)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				//The API can't mix uploaded and linked photos and links a single photo,
+				//so the post is imported with its first photo only.
+				params["source"] = media[0]
+				return nil, nil
+			}
+			file.Field = fmt.Sprintf("data[%d]", i)
+			files = append(files, file)
+		}
+		return files, nil
+	case AudioPost:
+		if post.Audio_url != "" {
+			file, ok, err := local(post.Audio_url)
+			if ok || err != nil {
+				file.Field = "data"
+				return []UploadFile{file}, err
+			}
+			params["external_url"] = post.Audio_url
+		}
+	case VideoPost:
+		if post.Video_url != "" {
+			file, ok, err := local(post.Video_url)
+			if ok || err != nil {
+				file.Field = "data"
+				return []UploadFile{file}, err
+			}
+		}
+		if len(post.Player) != 0 {
+			params["embed"] = post.Player[len(post.Player)-1].Embed_code
+		} else if post.Video_url != "" {
+			params["embed"] = post.Video_url
+		}
+	}
+	return nil, nil
+}
+
+//Writes the map of archived post ids to imported post ids, replacing the file at once
+//so that it is complete even if the import is interrupted.
+func writeImportMap(path string, ids map[string]string) error {
+	content, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,93 @@
+package gotumblr
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImportParams(t *testing.T) {
+	base := BasePost{Id: 7, State: "published", Tags: []string{"cats", "dogs"}, Date: "2014-02-14 18:30:00 GMT", Slug: "hello-world", Format: "html"}
+	common := func(params map[string]string) map[string]string {
+		params["tags"] = "cats,dogs"
+		params["date"] = "2014-02-14 18:30:00 GMT"
+		params["slug"] = "hello-world"
+		params["format"] = "html"
+		if params["state"] == "" {
+			params["state"] = "published"
+		}
+		return params
+	}
+	queued := base
+	queued.State = "queued"
+	publishOn := time.Date(2030, 10, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		post      Post
+		publishOn time.Time
+		postType  string
+		want      map[string]string
+	}{
+		{TextPost{BasePost: base, Title: "Hi", Body: "Hello"}, time.Time{}, "text", common(map[string]string{"title": "Hi", "body": "Hello"})},
+		{QuotePost{BasePost: queued, Text: "A happy heart."}, publishOn, "quote",
+			common(map[string]string{"quote": "A happy heart.", "state": "queue", "publish_on": "2030-10-31T12:00:00Z"})},
+		{LinkPost{BasePost: base, Url: "http://golang.org"}, time.Time{}, "link", common(map[string]string{"url": "http://golang.org"})},
+		{ChatPost{BasePost: base, Body: "Alice: Hi!"}, time.Time{}, "chat", common(map[string]string{"conversation": "Alice: Hi!"})},
+		{AnswerPost{BasePost: base, Question: "Why?", Answer: "Because."}, time.Time{}, "text",
+			common(map[string]string{"title": "Anonymous asked: Why?", "body": "Because."})},
+	}
+	for _, test := range tests {
+		postType, params := importParams(test.post, test.publishOn)
+		if postType != test.postType || !reflect.DeepEqual(params, test.want) {
+			t.Errorf("importParams(%+v) returned %v, %v, want %v, %v", test.post, postType, params, test.postType, test.want)
+		}
+	}
+}
+
+func TestImportMedia(t *testing.T) {
+	manifest := &ArchiveManifest{Media: map[string]string{"http://media.tumblr.com/cat.jpg": "media/7_0.jpg"}}
+	video := VideoPost{Video_url: "http://v.tumblr.com/cat.mp4", Player: []PlayerInfo{{Width: 250, Embed_code: "<small>"}, {Width: 500, Embed_code: "<large>"}}}
+	params := map[string]string{}
+	files, err := importMedia(t.TempDir(), manifest, video, params)
+	if err != nil || len(files) != 0 || params["embed"] != "<large>" {
+		t.Errorf("importMedia returned %v, %+v and set %v", files, err, params)
+	}
+
+	photo := PhotoPost{Photos: []PhotoObject{{Alt_sizes: []AltSize{{Width: 500, Url: "http://media.tumblr.com/cat.jpg"}}}}}
+	params = map[string]string{}
+	files, err = importMedia(t.TempDir(), manifest, photo, params)
+	if err != nil || len(files) != 0 || params["source"] != "http://media.tumblr.com/cat.jpg" {
+		t.Errorf("importMedia of a photo that isn't in the archive returned %v, %+v and set %v", files, err, params)
+	}
+}
+
+func TestImportArchiveSkipsPostsWithoutMedia(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva-art/post", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("type") != "text" {
+			t.Errorf("a %v post was created, want only the text post", r.FormValue("type"))
+		}
+		fmt.Fprint(w, `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": 9}}`)
+	})
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, ArchiveManifestFile), []byte(`{"blog": "mgterzieva", "media": {}}`), 0644)
+	posts := `{"raw": {"id": 8, "type": "text", "body": "Hi"}}
+{"raw": {"id": 7, "type": "photo", "photos": []}}
+`
+	ioutil.WriteFile(filepath.Join(dir, "posts.jsonl"), []byte(posts), 0644)
+
+	ids, err := client.ImportArchive(context.Background(), "https://MGTerzieva-Art.tumblr.com/", dir, ImportOptions{Sources: []string{"posts"}})
+	want := map[string]string{"7": "", "8": "9"}
+	if err != nil || !reflect.DeepEqual(ids, want) {
+		t.Errorf("ImportArchive returned %v, %+v, want %v", ids, err, want)
+	}
+	if !fileExists(filepath.Join(dir, "import_mgterzieva-art.json")) {
+		t.Errorf("the map file isn't named after the normalized blog identifier")
+	}
+}
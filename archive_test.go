@@ -0,0 +1,89 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVerifyArchive(t *testing.T) {
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "posts.jsonl"), []byte("{}\n"), 0644)
+	files, err := archiveFiles(dir)
+	if err != nil {
+		t.Fatalf("archiveFiles returned %+v, want %+v", err, nil)
+	}
+	want := ArchiveFile{Size: 3, Sha256: "ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356"}
+	if files["posts.jsonl"] != want {
+		t.Errorf("archiveFiles returned %+v, want %+v", files, want)
+	}
+	ioutil.WriteFile(filepath.Join(dir, ArchiveManifestFile), []byte(`{"files": {"posts.jsonl": {"size": 3, "sha256": "ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356"}, "avatar.png": {"size": 1}}}`), 0644)
+	err = VerifyArchive(dir)
+	if err == nil || !strings.HasSuffix(err.Error(), ": avatar.png is missing") {
+		t.Errorf("VerifyArchive returned %v, want avatar.png is missing", err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, "posts.jsonl"), []byte("{}\n{}\n"), 0644)
+	err = VerifyArchive(dir)
+	if err == nil || !strings.Contains(err.Error(), "posts.jsonl has changed") {
+		t.Errorf("VerifyArchive returned %v, want posts.jsonl has changed", err)
+	}
+}
+
+func TestExportPostsIncremental(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "posts.jsonl")
+	old := `{"raw": {"id": 2, "type": "text", "is_pinned": true}, "typed": {}}
+{"raw": {"id": 1, "type": "text"}, "typed": {}}
+`
+	ioutil.WriteFile(path, []byte(old), 0644)
+	page := []json.RawMessage{
+		json.RawMessage(`{"id": 2, "type": "text", "is_pinned": true}`),
+		json.RawMessage(`{"id": 4, "type": "text"}`),
+		json.RawMessage(`{"id": 3, "type": "text"}`),
+		json.RawMessage(`{"id": 1, "type": "text"}`),
+		json.RawMessage(`{"id": 0, "type": "text"}`),
+	}
+	it := &PostIterator{pager[json.RawMessage]{fetch: func(int) ([]json.RawMessage, error) {
+		return page, nil
+	}}}
+	records, err := exportPosts(path, it, true)
+	if err != nil {
+		t.Fatalf("exportPosts returned %+v, want %+v", err, nil)
+	}
+	ids := []int64{}
+	for _, record := range records {
+		var post BasePost
+		json.Unmarshal(record.Raw, &post)
+		ids = append(ids, post.Id)
+	}
+	if want := []int64{4, 3, 2, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("exportPosts archived the posts %v, want %v", ids, want)
+	}
+}
+
+func TestExportBlogWithoutAvatar(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/avatar/512", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"meta": {"status": 404, "msg": "Not Found"}}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"meta": {"status": 200, "msg": "OK"}, "response": {"blog": {"name": "mgterzieva"}, "posts": []}}`)
+	})
+
+	dir := t.TempDir()
+	manifest, err := client.ExportBlog(context.Background(), "mgterzieva", dir, ExportOptions{})
+	if err != nil || manifest.FailedAvatar != "Not Found" {
+		t.Fatalf("ExportBlog returned %+v, %+v, want the avatar failure in the manifest", manifest, err)
+	}
+	if _, err := client.ExportBlog(context.Background(), "https://MGTerzieva.tumblr.com/", dir, ExportOptions{}); err != nil {
+		t.Errorf("ExportBlog to the archive of the same blog returned %+v, want %+v", err, nil)
+	}
+}
@@ -0,0 +1,81 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+//The sizes of avatars, in pixels.
+var AvatarSizes = []int{16, 24, 30, 40, 48, 64, 96, 128, 512}
+
+//Retrieves the url of the blog's avatar.
+//The avatar endpoint redirects to the image, so the url is taken from the redirect
+//instead of downloading the image.
+//blogname: any blog identifier accepted by NormalizeBlogIdentifier.
+//size can be: 16, 24, 30, 40, 48, 64, 96, 128 or 512.
+func (trc *TumblrRestClient) AvatarURL(ctx context.Context, blogname string, size int) (string, error) {
+	if !validAvatarSize(size) {
+		return "", fmt.Errorf("gotumblr: invalid avatar size %d", size)
+	}
+	call := trc.request.newCall(ctx, "GET", blogPath(blogname, fmt.Sprintf("/avatar/%d", size)), map[string]string{})
+	call.noRedirect = true
+	data := trc.request.run(call)
+	if call.Err != nil {
+		return "", call.Err
+	}
+	if data.Meta.Status >= 400 || call.StatusCode >= 400 {
+		return "", errors.New(data.Meta.Msg)
+	}
+	var result struct {
+		Avatar_url string
+		Location   string
+	}
+	json.Unmarshal(data.Response, &result)
+	switch {
+	case result.Location != "":
+		return result.Location, nil
+	case result.Avatar_url != "":
+		return result.Avatar_url, nil
+	case call.StatusCode == http.StatusOK:
+		//the image itself was returned, so its url is the one of the endpoint
+		return trc.request.host + call.Endpoint, nil
+	}
+	return "", errors.New("gotumblr: the avatar response has no url")
+}
+
+//Downloads the image of the blog's avatar.
+//The caller must close the Body of the result.
+//blogname: any blog identifier accepted by NormalizeBlogIdentifier.
+//size can be: 16, 24, 30, 40, 48, 64, 96, 128 or 512.
+func (trc *TumblrRestClient) AvatarImage(ctx context.Context, blogname string, size int) (*AvatarImageResponse, error) {
+	avatarUrl, err := trc.AvatarURL(ctx, blogname, size)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := trc.request.download(ctx, avatarUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		httpResponse.Body.Close()
+		return nil, fmt.Errorf("gotumblr: downloading avatar %s: %s", avatarUrl, httpResponse.Status)
+	}
+	return &AvatarImageResponse{
+		Url:            avatarUrl,
+		Content_type:   httpResponse.Header.Get("Content-Type"),
+		Content_length: httpResponse.ContentLength,
+		Body:           httpResponse.Body,
+	}, nil
+}
+
+func validAvatarSize(size int) bool {
+	for _, s := range AvatarSizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package gotumblr
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+type AvatarImageResponse struct {
+	//The url the image was downloaded from.
+	Url string
+	//The MIME type of the image (e.g. image/png).
+	Content_type string
+	//The size of the image in bytes, or -1 if it is unknown.
+	Content_length int64
+	//The content of the image. It must be closed by the caller.
+	Body io.ReadCloser
+}
+
+//Reads the whole image and closes its Body.
+func (air *AvatarImageResponse) Bytes() ([]byte, error) {
+	defer air.Body.Close()
+	return ioutil.ReadAll(air.Body)
+}
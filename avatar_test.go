@@ -0,0 +1,92 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAvatarURLRedirect(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/avatar/512", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://media.tumblr.com/avatar_49f49d0b9209_512.png", http.StatusMovedPermanently)
+	})
+
+	avatar, err := client.AvatarURL(context.Background(), "mgterzieva.tumblr.com", 512)
+	want := "http://media.tumblr.com/avatar_49f49d0b9209_512.png"
+	if err != nil || avatar != want {
+		t.Errorf("AvatarURL returned %v, %v, want %v", avatar, err, want)
+	}
+	if avatar := client.Avatar("mgterzieva", 512).Avatar_url; avatar != want {
+		t.Errorf("Avatar returned %v, want %v", avatar, want)
+	}
+}
+
+func TestAvatarURLInvalidSize(t *testing.T) {
+	_, err := NewTumblrRestClient("", "", "", "", "", "http://api.tumblr.com").AvatarURL(context.Background(), "mgterzieva", 100)
+	if err == nil {
+		t.Errorf("AvatarURL returned %v, want an error", err)
+	}
+}
+
+func TestAvatarURLNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/nosuchblog/avatar/64", "GET", `{"meta": {"status": 404, "msg": "Not Found"}}`, map[string]string{}, t)
+
+	_, err := client.AvatarURL(context.Background(), "nosuchblog", 64)
+	if !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("AvatarURL returned %v, want Not Found", err)
+	}
+}
+
+func TestAvatarImage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/avatar/64", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL+"/avatar_64.png", http.StatusFound)
+	})
+	mux.HandleFunc("/avatar_64.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	})
+	var names []string
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			names = append(names, call.Name)
+			return next(call)
+		}
+	})
+
+	image, err := client.AvatarImage(context.Background(), "mgterzieva", 64)
+	if err != nil {
+		t.Fatalf("AvatarImage returned %v, want %v", err, nil)
+	}
+	content, err := image.Bytes()
+	if err != nil || string(content) != "png" {
+		t.Errorf("Bytes returned %q, %v, want png", content, err)
+	}
+	if image.Content_type != "image/png" || image.Url != server.URL+"/avatar_64.png" {
+		t.Errorf("AvatarImage returned %+v", image)
+	}
+	if want := []string{"/v2/blog/{blog}/avatar/{size}", "download"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("the middleware saw the calls %v, want %v", names, want)
+	}
+}
+
+func TestAvatarURLCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.AvatarURL(ctx, "mgterzieva", 64); err == nil {
+		t.Errorf("AvatarURL returned %v, want the context error", err)
+	}
+}
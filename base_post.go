@@ -1,9 +1,13 @@
 package gotumblr
 
+import "encoding/json"
+
 type BasePost struct {
 	Blog_name    string
+	Blog         PostBlog
 	Id           int64
 	Post_url     string
+	Slug         string
 	PostType     string `json:"type"`
 	Timestamp    int64
 	Date         string
@@ -19,6 +23,74 @@ type BasePost struct {
 	Total_Posts  int64
 	Note_count   int64
 	Notes        []Note
+	//Whether the post is pinned to the top of the blog.
+	Is_pinned bool
+	//Whether the post is labeled as mature content and what it contains.
+	Has_community_label        bool
+	Community_label_categories []CommunityLabelCategory
+}
+
+//The blog a post belongs to.
+type PostBlog struct {
+	Name    string
+	Title   string
+	Url     string
+	Uuid    string
+	Updated int64
+}
+
+//Post is implemented by BasePost and every post type, which embed it.
+type Post interface {
+	Base() BasePost
+}
+
+//Returns the fields common to all types of posts.
+func (p BasePost) Base() BasePost {
+	return p
+}
+
+//Parses a post into the type of post it is (e.g. a TextPost for text posts).
+//Posts of other types are parsed into a BasePost.
+func ParsePost(raw json.RawMessage) (Post, error) {
+	var base BasePost
+	if err := json.Unmarshal(raw, &base); err != nil {
+		return nil, err
+	}
+	switch base.PostType {
+	case "text":
+		var post TextPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "photo":
+		var post PhotoPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "quote":
+		var post QuotePost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "link":
+		var post LinkPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "chat":
+		var post ChatPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "audio":
+		var post AudioPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "video":
+		var post VideoPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	case "answer":
+		var post AnswerPost
+		err := json.Unmarshal(raw, &post)
+		return post, err
+	}
+	return base, nil
 }
 
 type Note struct {
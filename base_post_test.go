@@ -0,0 +1,28 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParsePost(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Post
+	}{
+		{`{"id": 1, "type": "text", "body": "Hello"}`, TextPost{BasePost: BasePost{Id: 1, PostType: "text"}, Body: "Hello"}},
+		{`{"id": 2, "type": "quote", "text": "Hi", "source": "Ziggy"}`, QuotePost{BasePost: BasePost{Id: 2, PostType: "quote"}, Text: "Hi", Source: "Ziggy"}},
+		{`{"id": 3, "type": "answer", "question": "Why?"}`, AnswerPost{BasePost: BasePost{Id: 3, PostType: "answer"}, Question: "Why?"}},
+		{`{"id": 4, "type": "blocks"}`, BasePost{Id: 4, PostType: "blocks"}},
+	}
+	for _, test := range tests {
+		post, err := ParsePost(json.RawMessage(test.raw))
+		if err != nil || !reflect.DeepEqual(post, test.want) {
+			t.Errorf("ParsePost(%v) returned %+v, %+v, want %+v", test.raw, post, err, test.want)
+		}
+	}
+	if _, err := ParsePost(json.RawMessage(`{"type": "text", "body": 1}`)); err == nil {
+		t.Errorf("ParsePost of a broken post returned %+v, want an error", err)
+	}
+}
@@ -0,0 +1,5 @@
+package gotumblr
+
+type BlocksResponse struct {
+	Blocked_tumblelogs []FollowedBlog
+}
@@ -4,6 +4,7 @@ type BlogInfo struct {
 	Title       string
 	Posts       int64
 	Name        string
+	Uuid        string
 	Url         string
 	Updated     int64
 	Description string
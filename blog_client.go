@@ -0,0 +1,324 @@
+package gotumblr
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//Makes requests scoped to a single blog through a Client.
+type BlogClient struct {
+	client Client
+	name   string
+}
+
+//Returns a BlogClient for the given blog.
+//blogname: any blog identifier accepted by NormalizeBlogIdentifier
+//(e.g. mgterzieva, mgterzieva.tumblr.com, a custom domain or a t: UUID).
+func (trc *TumblrRestClient) Blog(blogname string) *BlogClient {
+	return &BlogClient{trc, NormalizeBlogIdentifier(blogname)}
+}
+
+//Returns the normalized identifier of the blog.
+func (bc *BlogClient) Name() string {
+	return bc.name
+}
+
+//Gets general information about the blog.
+func (bc *BlogClient) Info() BlogInfoResponse {
+	return bc.client.BlogInfo(bc.name)
+}
+
+//Retrieves the url of the blog's avatar.
+//size can be: 16, 24, 30, 40, 48, 64, 96, 128 or 512.
+func (bc *BlogClient) Avatar(size int) AvatarResponse {
+	return bc.client.Avatar(bc.name, size)
+}
+
+//Retrieves the url of the blog's avatar. See TumblrRestClient.AvatarURL.
+func (bc *BlogClient) AvatarURL(ctx context.Context, size int) (string, error) {
+	return bc.client.AvatarURL(ctx, bc.name, size)
+}
+
+//Downloads the image of the blog's avatar. See TumblrRestClient.AvatarImage.
+func (bc *BlogClient) AvatarImage(ctx context.Context, size int) (*AvatarImageResponse, error) {
+	return bc.client.AvatarImage(ctx, bc.name, size)
+}
+
+//Gets a list of posts from the blog.
+//See TumblrRestClient.Posts for the postsType and options that can be used.
+func (bc *BlogClient) Posts(postsType string, options map[string]string) PostsResponse {
+	return bc.client.Posts(bc.name, postsType, options)
+}
+
+//Iterates over all published posts of the blog.
+//See TumblrRestClient.Posts for the postsType and options that can be used.
+func (bc *BlogClient) PostsIterator(ctx context.Context, postsType string, options map[string]string) *PostIterator {
+	return bc.client.PostsIterator(ctx, bc.name, postsType, options)
+}
+
+//Gets the likes of the blog.
+//See TumblrRestClient.BlogLikes for the options that can be used.
+func (bc *BlogClient) Likes(options map[string]string) LikesResponse {
+	return bc.client.BlogLikes(bc.name, options)
+}
+
+//Iterates over all posts the blog has liked.
+func (bc *BlogClient) LikesIterator(ctx context.Context) *PostIterator {
+	return bc.client.BlogLikesIterator(ctx, bc.name)
+}
+
+//Gets the followers of the blog.
+//See TumblrRestClient.Followers for the options that can be used.
+func (bc *BlogClient) Followers(options map[string]string) FollowersResponse {
+	return bc.client.Followers(bc.name, options)
+}
+
+//Iterates over all followers of the blog.
+func (bc *BlogClient) FollowersIterator(ctx context.Context) *UserIterator {
+	return bc.client.FollowersIterator(ctx, bc.name)
+}
+
+//Gets the blogs that the blog is following.
+//See TumblrRestClient.BlogFollowing for the options that can be used.
+func (bc *BlogClient) Following(options map[string]string) FollowingResponse {
+	return bc.client.BlogFollowing(bc.name, options)
+}
+
+//Iterates over all blogs that the blog is following.
+func (bc *BlogClient) FollowingIterator(ctx context.Context) *BlogIterator {
+	return bc.client.BlogFollowingIterator(ctx, bc.name)
+}
+
+//Checks whether the blog is followed by the query blog.
+func (bc *BlogClient) FollowedBy(query string) FollowedByResponse {
+	return bc.client.FollowedBy(bc.name, query)
+}
+
+//Gets the blogs that the blog has blocked.
+//See TumblrRestClient.Blocks for the options that can be used.
+func (bc *BlogClient) Blocks(options map[string]string) BlocksResponse {
+	return bc.client.Blocks(bc.name, options)
+}
+
+//Iterates over all blogs that the blog has blocked.
+func (bc *BlogClient) BlocksIterator(ctx context.Context) *BlogIterator {
+	return bc.client.BlocksIterator(ctx, bc.name)
+}
+
+//Blocks the target blog.
+func (bc *BlogClient) Block(target string) error {
+	return bc.client.Block(bc.name, target)
+}
+
+//Blocks the target blogs with a single request.
+func (bc *BlogClient) BulkBlock(targets []string) error {
+	return bc.client.BulkBlock(bc.name, targets)
+}
+
+//Unblocks the target blog.
+func (bc *BlogClient) Unblock(target string) error {
+	return bc.client.Unblock(bc.name, target)
+}
+
+//Gets the notifications of the blog, newest first.
+func (bc *BlogClient) Notifications(ctx context.Context, options NotificationOptions) (NotificationsResponse, error) {
+	return bc.client.Notifications(ctx, bc.name, options)
+}
+
+//Polls the notifications of the blog. See TumblrRestClient.NotificationStream.
+func (bc *BlogClient) NotificationStream(ctx context.Context, since time.Time, interval time.Duration, types ...NotificationType) *NotificationStream {
+	return bc.client.NotificationStream(ctx, bc.name, since, interval, types...)
+}
+
+//Gets posts that are currently in the blog's queue.
+//See TumblrRestClient.Queue for the options that can be used.
+func (bc *BlogClient) Queue(options map[string]string) DraftsResponse {
+	return bc.client.Queue(bc.name, options)
+}
+
+//Iterates over all posts in the blog's queue.
+func (bc *BlogClient) QueueIterator(ctx context.Context) *PostIterator {
+	return bc.client.QueueIterator(ctx, bc.name)
+}
+
+//Gets the posts that are currently in the blog's queue, in the order they will be published.
+func (bc *BlogClient) QueuedPosts(options map[string]string) []QueuedPost {
+	return bc.client.QueuedPosts(bc.name, options)
+}
+
+//Moves a post within the blog's queue. See TumblrRestClient.ReorderQueue.
+func (bc *BlogClient) ReorderQueue(postId, insertAfter string) error {
+	return bc.client.ReorderQueue(bc.name, postId, insertAfter)
+}
+
+//Shuffles the posts in the blog's queue into a random order.
+func (bc *BlogClient) ShuffleQueue() error {
+	return bc.client.ShuffleQueue(bc.name)
+}
+
+//Moves a draft to the end of the blog's queue.
+func (bc *BlogClient) QueueDraft(id string) error {
+	return bc.client.QueueDraft(bc.name, id)
+}
+
+//Queues a post to be published at the given time.
+func (bc *BlogClient) SchedulePost(id string, publishOn time.Time) error {
+	return bc.client.SchedulePost(bc.name, id, publishOn)
+}
+
+//Gets posts that are currently in the blog's drafts.
+//See TumblrRestClient.Drafts for the options that can be used.
+func (bc *BlogClient) Drafts(options map[string]string) DraftsResponse {
+	return bc.client.Drafts(bc.name, options)
+}
+
+//Iterates over all posts in the blog's drafts.
+func (bc *BlogClient) DraftsIterator(ctx context.Context) *PostIterator {
+	return bc.client.DraftsIterator(ctx, bc.name)
+}
+
+//Retrieves the blog's submission posts.
+//See TumblrRestClient.Submission for the options that can be used.
+func (bc *BlogClient) Submissions(options map[string]string) DraftsResponse {
+	return bc.client.Submission(bc.name, options)
+}
+
+//Iterates over all submissions to the blog.
+func (bc *BlogClient) SubmissionsIterator(ctx context.Context) *PostIterator {
+	return bc.client.SubmissionsIterator(ctx, bc.name)
+}
+
+//Gets the posts that are waiting in the blog's submissions.
+func (bc *BlogClient) PendingSubmissions(options map[string]string) []Submission {
+	return bc.client.PendingSubmissions(bc.name, options)
+}
+
+//Gets the questions that are waiting to be answered in the blog's submissions.
+func (bc *BlogClient) Asks(options map[string]string) []Ask {
+	return bc.client.Asks(bc.name, options)
+}
+
+//Answers a question. See TumblrRestClient.AnswerAsk.
+func (bc *BlogClient) AnswerAsk(id, answer string, options map[string]string) (PostResult, error) {
+	return bc.client.AnswerAsk(bc.name, id, answer, options)
+}
+
+//Publishes a submitted post. See TumblrRestClient.PublishSubmission.
+func (bc *BlogClient) PublishSubmission(id string, options map[string]string) (PostResult, error) {
+	return bc.client.PublishSubmission(bc.name, id, options)
+}
+
+//Declines a submission or question, deleting it.
+func (bc *BlogClient) DeclineSubmission(id string) error {
+	return bc.client.DeclineSubmission(bc.name, id)
+}
+
+//Sends a question to the blog's ask box.
+func (bc *BlogClient) SendAsk(question string, anonymous bool) error {
+	return bc.client.SendAsk(bc.name, question, anonymous)
+}
+
+//Submits a post to the blog. See TumblrRestClient.SubmitPost.
+func (bc *BlogClient) SubmitPost(postType string, options map[string]string) (PostResult, error) {
+	return bc.client.SubmitPost(bc.name, postType, options)
+}
+
+//Creates a photo post or photoset on the blog.
+//See TumblrRestClient.CreatePhoto for the options that can be used.
+func (bc *BlogClient) CreatePhoto(options map[string]string) (PostResult, error) {
+	return bc.client.CreatePhoto(bc.name, options)
+}
+
+//Creates a text post on the blog.
+//See TumblrRestClient.CreateText for the options that can be used.
+func (bc *BlogClient) CreateText(options map[string]string) (PostResult, error) {
+	return bc.client.CreateText(bc.name, options)
+}
+
+//Creates a quote post on the blog.
+//See TumblrRestClient.CreateQuote for the options that can be used.
+func (bc *BlogClient) CreateQuote(options map[string]string) (PostResult, error) {
+	return bc.client.CreateQuote(bc.name, options)
+}
+
+//Creates a link post on the blog.
+//See TumblrRestClient.CreateLink for the options that can be used.
+func (bc *BlogClient) CreateLink(options map[string]string) (PostResult, error) {
+	return bc.client.CreateLink(bc.name, options)
+}
+
+//Creates a chat post on the blog.
+//See TumblrRestClient.CreateChatPost for the options that can be used.
+func (bc *BlogClient) CreateChatPost(options map[string]string) (PostResult, error) {
+	return bc.client.CreateChatPost(bc.name, options)
+}
+
+//Creates an audio post on the blog.
+//See TumblrRestClient.CreateAudio for the options that can be used.
+func (bc *BlogClient) CreateAudio(options map[string]string) (PostResult, error) {
+	return bc.client.CreateAudio(bc.name, options)
+}
+
+//Creates a video post on the blog.
+//See TumblrRestClient.CreateVideo for the options that can be used.
+func (bc *BlogClient) CreateVideo(options map[string]string) (PostResult, error) {
+	return bc.client.CreateVideo(bc.name, options)
+}
+
+//Reblogs a post to the blog.
+//See TumblrRestClient.Reblog for the options that can be used.
+func (bc *BlogClient) Reblog(options map[string]string) (PostResult, error) {
+	return bc.client.Reblog(bc.name, options)
+}
+
+//Reblogs a post to the blog. See TumblrRestClient.ReblogPost.
+func (bc *BlogClient) ReblogPost(ctx context.Context, sourcePost Post, options ReblogOptions) (PostResult, error) {
+	return bc.client.ReblogPost(ctx, bc.name, sourcePost, options)
+}
+
+//Edits a post of the blog.
+//See TumblrRestClient.EditPost for the options that can be used.
+func (bc *BlogClient) Edit(options map[string]string) (PostResult, error) {
+	return bc.client.EditPost(bc.name, options)
+}
+
+//Deletes the post of the blog with the given id.
+func (bc *BlogClient) Delete(id string) error {
+	return bc.client.DeletePost(bc.name, id)
+}
+
+//Deletes or edits all posts of the blog that the selector matches. See TumblrRestClient.Bulk.
+func (bc *BlogClient) Bulk(ctx context.Context, selector PostSelector, action BulkAction, options BulkOptions) (*BulkReport, error) {
+	return bulk(ctx, bc.client, bc.name, selector, action, options)
+}
+
+//Renames tags on all posts of the blog that have them. See TumblrRestClient.RenameTags.
+func (bc *BlogClient) RenameTags(ctx context.Context, renames map[string]string, options BulkOptions) (*BulkReport, error) {
+	return renameTags(ctx, bc.client, bc.name, renames, options)
+}
+
+//Reverts the edits recorded in an undo log. See TumblrRestClient.UndoBulk.
+func (bc *BlogClient) UndoBulk(ctx context.Context, undoLog io.Reader, options BulkOptions) (*BulkReport, error) {
+	return undoBulk(ctx, bc.client, bc.name, undoLog, options)
+}
+
+//Mutes the notifications about a post of the blog. See TumblrRestClient.MutePost.
+func (bc *BlogClient) MutePost(id string, duration time.Duration) error {
+	return bc.client.MutePost(bc.name, id, duration)
+}
+
+//Unmutes the notifications about a post of the blog.
+func (bc *BlogClient) UnmutePost(id string) error {
+	return bc.client.UnmutePost(bc.name, id)
+}
+
+//Pins a post to the top of the blog.
+func (bc *BlogClient) PinPost(id string) error {
+	return bc.client.PinPost(bc.name, id)
+}
+
+//Unpins a post from the top of the blog.
+func (bc *BlogClient) UnpinPost(id string) error {
+	return bc.client.UnpinPost(bc.name, id)
+}
@@ -0,0 +1,149 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBlogIdentifier(t *testing.T) {
+	identifiers := map[string]string{
+		"mgterzieva":                                  "mgterzieva",
+		"MGTerzieva":                                  "mgterzieva",
+		" mgterzieva.tumblr.com ":                     "mgterzieva",
+		"http://mgterzieva.tumblr.com/":               "mgterzieva",
+		"https://mgterzieva.tumblr.com/post/1":        "mgterzieva",
+		"https://www.tumblr.com/mgterzieva":           "mgterzieva",
+		"https://www.tumblr.com/blog/view/mgterzieva": "mgterzieva",
+		"blog.example.com":                            "blog.example.com",
+		"http://Blog.Example.com/about":               "blog.example.com",
+		"t:2n7IE7gCC3tAi8XqOt8nfQ":                    "t:2n7IE7gCC3tAi8XqOt8nfQ",
+		"":                                            "",
+		"https://tumblr.com/":                         "",
+		"foo?x=1":                                     "",
+		"mgterzieva.tumblr.com#top":                   "",
+		"t:a/../../user":                              "",
+		"t:a%2F..":                                    "",
+		"mg terzieva":                                 "",
+	}
+	for identifier, want := range identifiers {
+		if got := NormalizeBlogIdentifier(identifier); got != want {
+			t.Errorf("NormalizeBlogIdentifier(%q) returned %v, want %v", identifier, got, want)
+		}
+	}
+}
+
+func TestBlog(t *testing.T) {
+	blog := NewTumblrRestClient("", "", "", "", "", "http://api.tumblr.com").Blog("http://mgterzieva.tumblr.com/")
+	want := "mgterzieva"
+	if blog.Name() != want {
+		t.Errorf("Blog name = %v, want %v", blog.Name(), want)
+	}
+}
+
+func TestEmptyBlogIdentifier(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("a request was sent to %v", r.URL.Path)
+	})
+
+	if _, err := client.AvatarURL(context.Background(), "tumblr.com/", 64); err == nil || err.Error() != "gotumblr: no blog identifier" {
+		t.Errorf("AvatarURL returned %+v, want an error about the blog identifier", err)
+	}
+	if _, err := client.AvatarURL(context.Background(), "t:a/../../user", 64); err == nil || err.Error() != "gotumblr: no blog identifier" {
+		t.Errorf("AvatarURL returned %+v, want an error about the blog identifier", err)
+	}
+	if err := client.DeletePost("", "7161981"); err == nil {
+		t.Errorf("DeletePost returned %+v, want an error", err)
+	}
+}
+
+func TestBlogInfoByHostname(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := `{"response": {"blog": {"name": "mgterzieva"}}}`
+
+	handleFunc("/v2/blog/mgterzieva/info", "GET", response, map[string]string{}, t)
+
+	info := client.BlogInfo("mgterzieva.tumblr.com").Blog
+	want := BlogInfo{Name: "mgterzieva"}
+	if info != want {
+		t.Errorf("BlogInfo returned %+v, want %+v", info, want)
+	}
+}
+
+func TestBlogClientInfo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := `{"response": {"blog": {"title": "Maria's blog"}}}`
+
+	handleFunc("/v2/blog/mgterzieva/info", "GET", response, map[string]string{}, t)
+
+	info := client.Blog("mgterzieva.tumblr.com").Info().Blog
+	want := BlogInfo{Title: "Maria's blog"}
+	if info != want {
+		t.Errorf("Info returned %+v, want %+v", info, want)
+	}
+}
+
+func TestBlogClientPosts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/blog.example.com/posts/text", "GET", `{"response": {"total_posts": 3}}`, map[string]string{}, t)
+
+	posts := client.Blog("http://blog.example.com/").Posts("text", map[string]string{})
+	want := int64(3)
+	if posts.Total_posts != want {
+		t.Errorf("Posts returned %+v, want %v", posts.Total_posts, want)
+	}
+}
+
+func TestBlogClientQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/t:2n7IE7gCC3tAi8XqOt8nfQ/posts/queue", "GET", `{"response": {"posts": []}}`, map[string]string{}, t)
+
+	queue := client.Blog("t:2n7IE7gCC3tAi8XqOt8nfQ").Queue(map[string]string{})
+	want := DraftsResponse{Posts: []json.RawMessage{}}
+	if !reflect.DeepEqual(queue, want) {
+		t.Errorf("Queue returned %+v, want %+v", queue, want)
+	}
+}
+
+func TestBlogClientCreateText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := `{"meta": {"status": 201, "msg": "Created"}}`
+
+	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"type": "text", "body": "Hello, hello!"}, t)
+
+	_, post_text := client.Blog("mgterzieva").CreateText(map[string]string{"body": "Hello, hello!"})
+	if post_text != nil {
+		t.Errorf("CreateText returned %+v, want %+v", post_text, nil)
+	}
+}
+
+func TestBlogClientDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	response := `{"meta": {"status": 400, "msg": "Bad Request"}}`
+
+	handleFunc("/v2/blog/mgterzieva/post/delete", "POST", response, map[string]string{"id": "123"}, t)
+
+	delete := client.Blog("mgterzieva").Delete("123")
+	want := errors.New("Bad Request")
+	if !reflect.DeepEqual(delete, want) {
+		t.Errorf("Delete returned %+v, want %+v", delete, want)
+	}
+}
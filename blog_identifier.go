@@ -0,0 +1,63 @@
+package gotumblr
+
+import (
+	"strings"
+	"unicode"
+)
+
+//Normalizes a blog identifier so that it can be used in a request url.
+//blogname can be:
+//a bare blog name (e.g. mgterzieva);
+//a standard hostname (e.g. mgterzieva.tumblr.com);
+//a custom domain (e.g. blog.example.com);
+//a blog url (e.g. http://mgterzieva.tumblr.com/ or https://www.tumblr.com/mgterzieva);
+//a blog UUID (e.g. t:2n7IE7gCC3tAi8XqOt8nfQ), which is returned as is.
+//Standard tumblr.com hostnames and urls are reduced to the bare blog name.
+//Identifiers that name no blog, such as "" or tumblr.com/, and identifiers that would change
+//the path or the parameters of a request, such as foo?x=1, are normalized to "";
+//requests made with them fail without being sent.
+func NormalizeBlogIdentifier(blogname string) string {
+	blogname = normalizeBlogIdentifier(blogname)
+	if strings.ContainsAny(blogname, "/?#%") || strings.IndexFunc(blogname, unicode.IsSpace) != -1 {
+		return ""
+	}
+	return blogname
+}
+
+//Does the work of NormalizeBlogIdentifier, without rejecting the identifiers that can't be used in a url.
+func normalizeBlogIdentifier(blogname string) string {
+	blogname = strings.TrimSpace(blogname)
+	if strings.HasPrefix(blogname, "t:") {
+		return blogname
+	}
+	blogname = strings.ToLower(blogname)
+	if i := strings.Index(blogname, "://"); i != -1 {
+		blogname = blogname[i+3:]
+	}
+	host, path := blogname, ""
+	if i := strings.Index(blogname, "/"); i != -1 {
+		host, path = blogname[:i], strings.Trim(blogname[i:], "/")
+	}
+	host = strings.TrimSuffix(host, ".")
+	if i := strings.Index(host, ":"); i != -1 {
+		host = host[:i]
+	}
+	if host == "tumblr.com" || host == "www.tumblr.com" {
+		path = strings.TrimPrefix(path, "blog/view/")
+		if i := strings.Index(path, "/"); i != -1 {
+			path = path[:i]
+		}
+		return path
+	}
+	if strings.HasSuffix(host, ".tumblr.com") {
+		return strings.TrimSuffix(host, ".tumblr.com")
+	}
+	return host
+}
+
+//Builds the url of a blog endpoint.
+//blogname: any blog identifier accepted by NormalizeBlogIdentifier.
+//path: the part of the url following the blog identifier (e.g. /posts/queue).
+func blogPath(blogname, path string) string {
+	return "/v2/blog/" + NormalizeBlogIdentifier(blogname) + path
+}
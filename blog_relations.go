@@ -0,0 +1,130 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//Gets the blogs that the blog is following.
+//blogname: name of the blog whose followed blogs you want to get.
+//options can be:
+//limit: the number of results to return, 1-20;
+//offset: result number to start at.
+func (trc *TumblrRestClient) BlogFollowing(blogname string, options map[string]string) FollowingResponse {
+	requestUrl := blogPath(blogname, "/following")
+	data := trc.request.Get(requestUrl, options)
+	var result FollowingResponse
+	json.Unmarshal(data.Response, &result)
+	return result
+}
+
+//Checks whether the blog is followed by another blog.
+//blogname: name of the blog that may be followed.
+//query: the blog that may be following it.
+func (trc *TumblrRestClient) FollowedBy(blogname, query string) FollowedByResponse {
+	requestUrl := blogPath(blogname, "/followed_by")
+	params := map[string]string{"query": NormalizeBlogIdentifier(query)}
+	data := trc.request.Get(requestUrl, params)
+	var result FollowedByResponse
+	json.Unmarshal(data.Response, &result)
+	return result
+}
+
+//Gets the blogs that the blog has blocked.
+//blogname: name of the blog whose blocks you want to get.
+//options can be:
+//limit: the number of results to return, 1-20;
+//offset: result number to start at.
+func (trc *TumblrRestClient) Blocks(blogname string, options map[string]string) BlocksResponse {
+	requestUrl := blogPath(blogname, "/blocks")
+	data := trc.request.Get(requestUrl, options)
+	var result BlocksResponse
+	json.Unmarshal(data.Response, &result)
+	return result
+}
+
+//Blocks a blog.
+//blogname: name of the blog that blocks.
+//target: the blog to block.
+func (trc *TumblrRestClient) Block(blogname, target string) error {
+	requestUrl := blogPath(blogname, "/blocks")
+	params := map[string]string{"blocked_tumblelog": NormalizeBlogIdentifier(target)}
+	data := trc.request.Post(requestUrl, params)
+	if data.Meta.Status != 200 && data.Meta.Status != 201 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Blocks several blogs with a single request.
+//blogname: name of the blog that blocks.
+//targets: the blogs to block.
+func (trc *TumblrRestClient) BulkBlock(blogname string, targets []string) error {
+	requestUrl := blogPath(blogname, "/blocks/bulk")
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = NormalizeBlogIdentifier(target)
+	}
+	params := map[string]string{"blocked_tumblelogs": strings.Join(names, ",")}
+	data := trc.request.Post(requestUrl, params)
+	if data.Meta.Status != 200 && data.Meta.Status != 201 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Unblocks a blog.
+//blogname: name of the blog that blocked it.
+//target: the blog to unblock.
+func (trc *TumblrRestClient) Unblock(blogname, target string) error {
+	requestUrl := blogPath(blogname, "/blocks")
+	params := map[string]string{"blocked_tumblelog": NormalizeBlogIdentifier(target)}
+	data := trc.request.Delete(requestUrl, params)
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Iterates over all followers of the blog, requesting them a page at a time.
+func (trc *TumblrRestClient) FollowersIterator(ctx context.Context, blogname string) *UserIterator {
+	requestUrl := blogPath(blogname, "/followers")
+	return &UserIterator{pager[User]{fetch: offsetPages(ctx, trc, requestUrl, nil, func(result *FollowersResponse) []User {
+		return result.Users
+	})}}
+}
+
+//Iterates over all blogs that the blog is following, requesting them a page at a time.
+func (trc *TumblrRestClient) BlogFollowingIterator(ctx context.Context, blogname string) *BlogIterator {
+	requestUrl := blogPath(blogname, "/following")
+	return &BlogIterator{pager[FollowedBlog]{fetch: offsetPages(ctx, trc, requestUrl, nil, func(result *FollowingResponse) []FollowedBlog {
+		return result.Blogs
+	})}}
+}
+
+//Iterates over all blogs that the blog has blocked, requesting them a page at a time.
+func (trc *TumblrRestClient) BlocksIterator(ctx context.Context, blogname string) *BlogIterator {
+	requestUrl := blogPath(blogname, "/blocks")
+	return &BlogIterator{pager[FollowedBlog]{fetch: offsetPages(ctx, trc, requestUrl, nil, func(result *BlocksResponse) []FollowedBlog {
+		return result.Blocked_tumblelogs
+	})}}
+}
+
+//Requests the page of results that starts at offset and parses it into result.
+//options are sent along with the offset and the limit of the page, which replace the ones in options.
+func (trc *TumblrRestClient) page(ctx context.Context, requestUrl string, options map[string]string, offset int, result interface{}) error {
+	params := map[string]string{}
+	for key, value := range options {
+		params[key] = value
+	}
+	params["offset"] = strconv.Itoa(offset)
+	params["limit"] = strconv.Itoa(iteratorPageSize)
+	data := trc.request.GetContext(ctx, requestUrl, params)
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return json.Unmarshal(data.Response, result)
+}
@@ -0,0 +1,154 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestBlogFollowing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/following", "GET", `{"response": {"total_blogs": 1, "blogs": [{"name": "thehungergamesmovie"}]}}`, map[string]string{"limit": "1"}, t)
+
+	following := client.BlogFollowing("mgterzieva.tumblr.com", map[string]string{"limit": "1"})
+	want := FollowingResponse{Total_blogs: 1, Blogs: []FollowedBlog{{Name: "thehungergamesmovie"}}}
+	if !reflect.DeepEqual(following, want) {
+		t.Errorf("BlogFollowing returned %+v, want %+v", following, want)
+	}
+}
+
+func TestFollowedBy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/followed_by", "GET", `{"response": {"followed_by": true}}`, map[string]string{"query": "thehungergamesmovie"}, t)
+
+	if followedBy := client.FollowedBy("mgterzieva", "thehungergamesmovie.tumblr.com"); !followedBy.Followed_by {
+		t.Errorf("FollowedBy returned %+v, want %+v", followedBy.Followed_by, true)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/blocks", "GET", `{"response": {"blocked_tumblelogs": [{"name": "spam"}]}}`, map[string]string{}, t)
+
+	blocks := client.Blocks("mgterzieva", map[string]string{})
+	want := BlocksResponse{Blocked_tumblelogs: []FollowedBlog{{Name: "spam"}}}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("Blocks returned %+v, want %+v", blocks, want)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/blocks", "POST", `{"meta": {"status": 201, "msg": "Created"}}`, map[string]string{"blocked_tumblelog": "spam"}, t)
+
+	if err := client.Block("mgterzieva", "http://spam.tumblr.com/"); err != nil {
+		t.Errorf("Block returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestBulkBlock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/blocks/bulk", "POST", `{"meta": {"status": 201, "msg": "Created"}}`, map[string]string{"blocked_tumblelogs": "spam,morespam"}, t)
+
+	if err := client.BulkBlock("mgterzieva", []string{"spam", "morespam.tumblr.com"}); err != nil {
+		t.Errorf("BulkBlock returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestUnblock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/blocks", "DELETE", `{"meta": {"status": 404, "msg": "Not Found"}}`, map[string]string{"blocked_tumblelog": "spam"}, t)
+
+	err := client.Unblock("mgterzieva", "spam")
+	if !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("Unblock returned %+v, want Not Found", err)
+	}
+}
+
+func TestFollowersIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/followers", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.FormValue("offset"))
+		if r.FormValue("limit") != "20" {
+			t.Errorf("limit should be 20")
+		}
+		users := ""
+		for i := offset; i < offset+20 && i < 25; i++ {
+			if users != "" {
+				users += ","
+			}
+			users += fmt.Sprintf(`{"name": "user%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"meta": {"status": 200, "msg": "OK"}, "response": {"total_users": 25, "users": [%s]}}`, users)
+	})
+
+	it := client.FollowersIterator(context.Background(), "mgterzieva")
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.User().Name)
+	}
+	if it.Err() != nil || len(names) != 25 || names[24] != "user24" {
+		t.Errorf("FollowersIterator returned %v users, %v, want 25 users", len(names), it.Err())
+	}
+}
+
+func TestBlocksIteratorError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/blocks", "GET", `{"meta": {"status": 403, "msg": "Forbidden"}}`, map[string]string{}, t)
+
+	it := client.BlocksIterator(context.Background(), "mgterzieva")
+	if it.Next() {
+		t.Errorf("Next returned %v, want %v", true, false)
+	}
+	if !reflect.DeepEqual(it.Err(), errors.New("Forbidden")) {
+		t.Errorf("Err returned %+v, want Forbidden", it.Err())
+	}
+}
+
+func TestNilIterator(t *testing.T) {
+	var it *BlogIterator
+	if it.Next() || it.Err() != nil {
+		t.Errorf("a nil BlogIterator should be empty")
+	}
+	if (&BlogIterator{}).Next() || (&UserIterator{}).Next() || (&PostIterator{}).Next() {
+		t.Errorf("zero iterators should be empty")
+	}
+}
+
+func TestNewIterators(t *testing.T) {
+	users := NewUserIterator(User{Name: "mgterzieva"}, User{Name: "staff"})
+	names := []string{}
+	for users.Next() {
+		names = append(names, users.User().Name)
+	}
+	if !reflect.DeepEqual(names, []string{"mgterzieva", "staff"}) || users.Err() != nil {
+		t.Errorf("NewUserIterator iterated over %v, %+v", names, users.Err())
+	}
+	blogs := NewBlogIterator(FollowedBlog{Name: "staff"})
+	if !blogs.Next() || blogs.Blog().Name != "staff" || blogs.Next() {
+		t.Errorf("NewBlogIterator didn't iterate over the blog")
+	}
+	if NewPostIterator().Next() {
+		t.Errorf("NewPostIterator without posts should be empty")
+	}
+}
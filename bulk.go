@@ -0,0 +1,442 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//The number of posts a bulk operation acts on at the same time when BulkOptions.Concurrency is not set.
+const DefaultBulkConcurrency = 4
+
+//Selects the posts of a blog that a bulk operation acts on.
+//The zero value selects all published, queued and draft posts.
+type PostSelector struct {
+	//Only posts with this tag, if not empty.
+	Tag string
+	//Only posts of this type (e.g. text or photo), if not empty.
+	Type string
+	//Only posts in these states (published, queued or draft), if not empty.
+	//Private posts can't be selected, since the listing of published posts doesn't return them.
+	States []string
+	//Only posts published at or after Since and before Until, if they are not zero.
+	Since time.Time
+	Until time.Time
+}
+
+//Reports whether the post is selected.
+func (s PostSelector) Match(post BasePost) bool {
+	if s.Tag != "" && !containsTag(post.Tags, s.Tag) {
+		return false
+	}
+	if s.Type != "" && post.PostType != s.Type {
+		return false
+	}
+	if !s.hasState(post.State) {
+		return false
+	}
+	published := time.Unix(post.Timestamp, 0)
+	if !s.Since.IsZero() && published.Before(s.Since) {
+		return false
+	}
+	if !s.Until.IsZero() && !published.Before(s.Until) {
+		return false
+	}
+	return true
+}
+
+func (s PostSelector) hasState(state string) bool {
+	if len(s.States) == 0 {
+		return true
+	}
+	for _, selected := range s.States {
+		if selected == state || selected == "queue" && state == "queued" {
+			return true
+		}
+	}
+	return false
+}
+
+//What a bulk operation does to each selected post.
+//Delete can't be combined with the other changes, which are made with a single EditPost per post.
+type BulkAction struct {
+	//Delete the posts.
+	Delete bool `json:"delete,omitempty"`
+	//Tags to add to the posts.
+	AddTags []string `json:"add_tags,omitempty"`
+	//Tags to remove from the posts.
+	RemoveTags []string `json:"remove_tags,omitempty"`
+	//Tags to rename, from the old name to the new one. Renaming several tags to the same name merges them.
+	//Old names are matched ignoring case, so no two of them may differ only by case.
+	RenameTags map[string]string `json:"rename_tags,omitempty"`
+	//The new state of the posts (published, private, queue or draft), if not empty.
+	State string `json:"state,omitempty"`
+}
+
+//Returns the options of EditPost that make the changes of the action to the post,
+//or nil if the post already is the way the action would leave it.
+func (a BulkAction) edit(post BasePost) map[string]string {
+	options := map[string]string{"id": strconv.FormatInt(post.Id, 10)}
+	tags := []string{}
+	for _, tag := range post.Tags {
+		tag = a.rename(tag)
+		if !containsTag(a.RemoveTags, tag) && !containsTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range a.AddTags {
+		if !containsTag(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if !sameTags(tags, post.Tags) {
+		options["tags"] = strings.Join(tags, ",")
+	}
+	if a.State != "" && a.State != post.State && !(a.State == "queue" && post.State == "queued") {
+		options["state"] = a.State
+	}
+	if len(options) == 1 {
+		return nil
+	}
+	return options
+}
+
+//Returns an error if two of the old names of the renames differ only by case,
+//which would make the new name of their tag depend on the order of the map.
+func checkRenames(renames map[string]string) error {
+	olds := []string{}
+	for old := range renames {
+		if containsTag(olds, old) {
+			return fmt.Errorf("gotumblr: the tag %q is renamed twice", old)
+		}
+		olds = append(olds, old)
+	}
+	return nil
+}
+
+//Returns the new name of the tag, which is the tag itself if the action doesn't rename it.
+func (a BulkAction) rename(tag string) string {
+	for old, renamed := range a.RenameTags {
+		if strings.EqualFold(old, tag) {
+			return renamed
+		}
+	}
+	return tag
+}
+
+//Options of a bulk operation.
+type BulkOptions struct {
+	//The number of posts acted on at the same time. DefaultBulkConcurrency if zero.
+	Concurrency int
+	//The minimum time between two requests that change posts. No limit if zero.
+	Interval time.Duration
+	//Only select the posts, without changing them. Their items in the report are BulkPlanned.
+	DryRun bool
+	//Called after each post is handled, one call at a time.
+	Progress func(BulkEvent)
+	//The report of an earlier run of the same operation. The posts it lists as succeeded are skipped.
+	//The operation fails if the report is of another blog or action.
+	Resume *BulkReport
+	//If not nil, a BulkUndoEntry is written to it as a line of JSON before every edit of a post,
+	//so that UndoBulk can revert the edits. It is synced after each entry if it has a Sync method, like *os.File.
+	//Posts aren't edited once writing to it fails. Deleted posts can't be restored.
+	UndoLog io.Writer
+}
+
+//The state of a post in a bulk operation.
+type BulkStatus string
+
+const (
+	//The post was selected but not handled yet, e.g. because the operation was canceled.
+	BulkPending BulkStatus = "pending"
+	//The post would be changed, in a dry run.
+	BulkPlanned BulkStatus = "planned"
+	//The post was changed.
+	BulkSucceeded BulkStatus = "succeeded"
+	//Changing the post failed. The error is in the item.
+	BulkFailed BulkStatus = "failed"
+	//The post didn't need changing or was changed by an earlier run.
+	BulkSkipped BulkStatus = "skipped"
+)
+
+//A post selected by a bulk operation.
+type BulkItem struct {
+	ID     string     `json:"id"`
+	Url    string     `json:"url,omitempty"`
+	Status BulkStatus `json:"status"`
+	Error  string     `json:"error,omitempty"`
+}
+
+//Reports the progress of a bulk operation.
+type BulkEvent struct {
+	//The post that was just handled.
+	Item BulkItem
+	//The number of posts handled so far and the number of selected posts.
+	Done  int
+	Total int
+}
+
+//The outcome of a bulk operation, one item per selected post.
+//Save it to resume the operation later with BulkOptions.Resume.
+type BulkReport struct {
+	Blog   string     `json:"blog"`
+	Action BulkAction `json:"action"`
+	DryRun bool       `json:"dry_run,omitempty"`
+	Items  []BulkItem `json:"items"`
+}
+
+//Returns the number of items with the given status.
+func (r *BulkReport) Count(status BulkStatus) int {
+	count := 0
+	for _, item := range r.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+//Writes the report to a file as JSON.
+func (r *BulkReport) Save(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+//Reads a report written by BulkReport.Save.
+func LoadBulkReport(path string) (*BulkReport, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := &BulkReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+//Deletes or edits all posts of the blog that the selector matches.
+//All matching posts are selected before any is changed, so changes don't affect the selection.
+//Failures of single posts are recorded in the report; the returned error is about
+//selecting the posts or the operation being canceled, in which case the report lists
+//the posts that weren't handled as BulkPending.
+func (trc *TumblrRestClient) Bulk(ctx context.Context, blogname string, selector PostSelector, action BulkAction, options BulkOptions) (*BulkReport, error) {
+	return bulk(ctx, trc, blogname, selector, action, options)
+}
+
+//Does the work of Bulk with any Client, such as the one a BlogClient is made with.
+func bulk(ctx context.Context, client Client, blogname string, selector PostSelector, action BulkAction, options BulkOptions) (*BulkReport, error) {
+	if action.Delete && (len(action.AddTags) != 0 || len(action.RemoveTags) != 0 || len(action.RenameTags) != 0 || action.State != "") {
+		return nil, errors.New("gotumblr: a bulk delete can't change posts")
+	}
+	if err := checkRenames(action.RenameTags); err != nil {
+		return nil, err
+	}
+	posts, err := selectPosts(ctx, client, blogname, selector)
+	if err != nil {
+		return nil, err
+	}
+	report := &BulkReport{Blog: blogname, Action: action, DryRun: options.DryRun}
+	return report, runBulk(ctx, client, blogname, report, posts, action.Delete, action.edit, options)
+}
+
+//Reports whether the two reports are of the same blog and action.
+//The actions are compared as JSON, which is how a loaded report got its action.
+func sameOperation(a, b *BulkReport) bool {
+	if NormalizeBlogIdentifier(a.Blog) != NormalizeBlogIdentifier(b.Blog) {
+		return false
+	}
+	actionA, errA := json.Marshal(a.Action)
+	actionB, errB := json.Marshal(b.Action)
+	return errA == nil && errB == nil && string(actionA) == string(actionB)
+}
+
+//Deletes the posts, or edits them with the options returned by edit, filling the report.
+//Posts for which edit returns nil are skipped.
+func runBulk(ctx context.Context, client Client, blogname string, report *BulkReport, posts []BasePost, delete bool, edit func(BasePost) map[string]string, options BulkOptions) error {
+	if options.Resume != nil && !sameOperation(options.Resume, report) {
+		return errors.New("gotumblr: the resumed report is of another operation")
+	}
+	report.Items = make([]BulkItem, len(posts))
+	done := map[string]bool{}
+	if options.Resume != nil {
+		for _, item := range options.Resume.Items {
+			if item.Status == BulkSucceeded {
+				done[item.ID] = true
+			}
+		}
+	}
+	for i, post := range posts {
+		report.Items[i] = BulkItem{ID: strconv.FormatInt(post.Id, 10), Url: post.Post_url, Status: BulkPending}
+	}
+
+	var mu sync.Mutex
+	handled := 0
+	var undoErr error
+	//Writes the undo entry of an edit before it is made, so that the log covers every edit
+	//even if the operation is interrupted. Edits aren't made once writing the log failed.
+	logUndo := func(i int, changes map[string]string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if undoErr == nil {
+			undoErr = writeUndoEntry(options.UndoLog, posts[i], changes)
+		}
+		return undoErr
+	}
+	finish := func(i int, status BulkStatus, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Items[i].Status = status
+		if err != nil {
+			report.Items[i].Error = err.Error()
+		}
+		handled++
+		if options.Progress != nil {
+			options.Progress(BulkEvent{Item: report.Items[i], Done: handled, Total: len(posts)})
+		}
+	}
+	var tick <-chan time.Time
+	if options.Interval > 0 && !options.DryRun {
+		ticker := time.NewTicker(options.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var changes map[string]string
+				if !delete {
+					changes = edit(posts[i])
+				}
+				switch {
+				case done[report.Items[i].ID] || !delete && changes == nil:
+					finish(i, BulkSkipped, nil)
+				case options.DryRun:
+					finish(i, BulkPlanned, nil)
+				default:
+					if tick != nil {
+						select {
+						case <-tick:
+						case <-ctx.Done():
+							continue
+						}
+					}
+					if ctx.Err() != nil {
+						continue
+					}
+					var err error
+					switch {
+					case delete:
+						err = client.DeletePost(blogname, report.Items[i].ID)
+					case options.UndoLog != nil:
+						if err = logUndo(i, changes); err == nil {
+							_, err = client.EditPost(blogname, changes)
+						}
+					default:
+						_, err = client.EditPost(blogname, changes)
+					}
+					if err != nil {
+						finish(i, BulkFailed, err)
+					} else {
+						finish(i, BulkSucceeded, nil)
+					}
+				}
+			}
+		}()
+	}
+	for i := range posts {
+		if ctx.Err() != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return undoErr
+}
+
+//Returns the posts of the blog that the selector matches: the published posts, then the queued ones
+//and then the drafts, each newest first.
+//Private posts can't be selected, since PostsIterator doesn't return them.
+func selectPosts(ctx context.Context, client Client, blogname string, selector PostSelector) ([]BasePost, error) {
+	for _, state := range selector.States {
+		if state == "private" {
+			return nil, errors.New("gotumblr: private posts can't be selected")
+		}
+	}
+	iterators := []*PostIterator{}
+	if selector.hasState("published") {
+		options := map[string]string{}
+		if selector.Tag != "" {
+			options["tag"] = selector.Tag
+		}
+		iterators = append(iterators, client.PostsIterator(ctx, blogname, selector.Type, options))
+	}
+	if selector.hasState("queued") {
+		iterators = append(iterators, client.QueueIterator(ctx, blogname))
+	}
+	if selector.hasState("draft") {
+		iterators = append(iterators, client.DraftsIterator(ctx, blogname))
+	}
+	posts := []BasePost{}
+	seen := map[int64]bool{}
+	for _, it := range iterators {
+		for it.Next() {
+			var post BasePost
+			if err := json.Unmarshal(it.Post(), &post); err != nil {
+				return nil, err
+			}
+			if !seen[post.Id] && selector.Match(post) {
+				seen[post.Id] = true
+				posts = append(posts, post)
+			}
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return posts, nil
+}
+
+//Reports whether the tags contain the tag, ignoring case as Tumblr does.
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package gotumblr
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPostSelectorMatch(t *testing.T) {
+	post := BasePost{Id: 1, PostType: "text", State: "queued", Tags: []string{"WIP", "cats"}, Timestamp: 1414713600}
+	tests := []struct {
+		selector PostSelector
+		want     bool
+	}{
+		{PostSelector{}, true},
+		{PostSelector{Tag: "wip"}, true},
+		{PostSelector{Tag: "dogs"}, false},
+		{PostSelector{Type: "text"}, true},
+		{PostSelector{Type: "photo"}, false},
+		{PostSelector{States: []string{"draft", "queue"}}, true},
+		{PostSelector{States: []string{"published"}}, false},
+		{PostSelector{Since: time.Unix(1414713600, 0), Until: time.Unix(1414713601, 0)}, true},
+		{PostSelector{Since: time.Unix(1414713601, 0)}, false},
+		{PostSelector{Until: time.Unix(1414713600, 0)}, false},
+	}
+	for _, test := range tests {
+		if got := test.selector.Match(post); got != test.want {
+			t.Errorf("%+v.Match returned %v, want %v", test.selector, got, test.want)
+		}
+	}
+}
+
+func TestBulkActionEdit(t *testing.T) {
+	post := BasePost{Id: 7, State: "draft", Tags: []string{"wip", "cats"}}
+	tests := []struct {
+		action BulkAction
+		want   map[string]string
+	}{
+		{BulkAction{AddTags: []string{"dogs"}}, map[string]string{"id": "7", "tags": "wip,cats,dogs"}},
+		{BulkAction{AddTags: []string{"Cats"}}, nil},
+		{BulkAction{RemoveTags: []string{"WIP"}, State: "queue"}, map[string]string{"id": "7", "tags": "cats", "state": "queue"}},
+		{BulkAction{RemoveTags: []string{"wip", "cats"}}, map[string]string{"id": "7", "tags": ""}},
+		{BulkAction{State: "draft"}, nil},
+	}
+	for _, test := range tests {
+		if got := test.action.edit(post); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%+v.edit returned %v, want %v", test.action, got, test.want)
+		}
+	}
+}
+
+func TestBulkReportSaveAndLoad(t *testing.T) {
+	report := &BulkReport{
+		Blog:   "mgterzieva",
+		Action: BulkAction{AddTags: []string{"xoxo"}},
+		Items:  []BulkItem{{ID: "1", Status: BulkSucceeded}, {ID: "2", Status: BulkFailed, Error: "Not Found"}},
+	}
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := report.Save(path); err != nil {
+		t.Fatalf("Save returned %+v, want %+v", err, nil)
+	}
+	loaded, err := LoadBulkReport(path)
+	if err != nil || !reflect.DeepEqual(loaded, report) {
+		t.Errorf("LoadBulkReport returned %+v, %+v, want %+v", loaded, err, report)
+	}
+	if count := loaded.Count(BulkFailed); count != 1 {
+		t.Errorf("Count returned %v, want %v", count, 1)
+	}
+}
+
+func TestBulkRejectsDeleteWithChanges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Bulk(context.Background(), "mgterzieva", PostSelector{}, BulkAction{Delete: true, State: "draft"}, BulkOptions{})
+	if err == nil {
+		t.Errorf("Bulk returned %+v, want an error", err)
+	}
+}
+
+func TestBulkRejectsPrivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.Bulk(context.Background(), "mgterzieva", PostSelector{States: []string{"published", "private"}}, BulkAction{State: "draft"}, BulkOptions{})
+	if err == nil || err.Error() != "gotumblr: private posts can't be selected" {
+		t.Errorf("Bulk returned %+v, want an error about private posts", err)
+	}
+}
+
+func TestBulkRejectsRenamesDifferingByCase(t *testing.T) {
+	setup()
+	defer teardown()
+
+	action := BulkAction{RenameTags: map[string]string{"WIP": "done", "wip": "drafts"}}
+	if _, err := client.Bulk(context.Background(), "mgterzieva", PostSelector{}, action, BulkOptions{}); err == nil {
+		t.Errorf("Bulk returned %+v, want an error", err)
+	}
+	if _, err := client.RenameTags(context.Background(), "mgterzieva", action.RenameTags, BulkOptions{}); err == nil {
+		t.Errorf("RenameTags returned %+v, want an error", err)
+	}
+}
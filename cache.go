@@ -0,0 +1,139 @@
+package gotumblr
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+//A response stored in a Cache.
+type CacheEntry struct {
+	Response CompleteResponse
+	//The validators of the response, used to make conditional requests once it expires.
+	ETag         string
+	LastModified string
+	//The blog the response is about; empty for user endpoints.
+	Blog string
+	//When the response stops being fresh.
+	Expires time.Time
+}
+
+//Cache stores responses of GET requests.
+//NewLRUCache and NewDiskCache return implementations of it.
+//Implementations must be safe for concurrent use.
+type Cache interface {
+	//Returns the entry stored under the key, even if it has expired.
+	Get(key string) (CacheEntry, bool)
+	//Stores the entry under the key.
+	Set(key string, entry CacheEntry)
+	//Removes the entry stored under the key.
+	Delete(key string)
+	//Removes all entries about the given blog; an empty blog removes the entries of user endpoints.
+	DeleteBlog(blog string)
+}
+
+//Configures the response cache of a TumblrRequest.
+type CacheOptions struct {
+	//Where responses are stored.
+	Cache Cache
+	//How long responses of each endpoint stay fresh, by endpoint name (e.g. /v2/blog/{blog}/info).
+	TTL map[string]time.Duration
+	//How long responses of endpoints that are not in TTL stay fresh.
+	//0 means that only the endpoints in TTL are cached.
+	DefaultTTL time.Duration
+}
+
+type cacheLayer struct {
+	CacheOptions
+}
+
+//Turns on caching of GET responses. Responses are cached by endpoint and parameters,
+//excluding authentication. Once a response expires and it has an ETag or a Last-Modified date,
+//it is revalidated with a conditional request. POST requests about a blog remove its cached responses.
+//options: a nil Cache turns caching off.
+func (tr *TumblrRequest) SetCache(options CacheOptions) {
+	if options.Cache == nil {
+		tr.cache = nil
+		return
+	}
+	tr.cache = &cacheLayer{options}
+}
+
+//Turns on caching of GET responses. See TumblrRequest.SetCache.
+func (trc *TumblrRestClient) SetCache(options CacheOptions) {
+	trc.request.SetCache(options)
+}
+
+//Removes the cached responses about the given blog.
+//blogname: any blog identifier accepted by NormalizeBlogIdentifier.
+func (tr *TumblrRequest) InvalidateBlog(blogname string) {
+	if tr.cache != nil {
+		tr.cache.Cache.DeleteBlog(NormalizeBlogIdentifier(blogname))
+	}
+}
+
+//Removes the cached responses about the given blog. See TumblrRequest.InvalidateBlog.
+func (trc *TumblrRestClient) InvalidateBlog(blogname string) {
+	trc.request.InvalidateBlog(blogname)
+}
+
+//Answers the call from the cache when possible, otherwise sends it and caches the response.
+func (c *cacheLayer) do(call *APICall, send Handler) CompleteResponse {
+	if call.Method != "GET" {
+		response := send(call)
+		if call.Err == nil {
+			c.Cache.DeleteBlog(call.Blog)
+		}
+		return response
+	}
+	ttl, ok := c.TTL[call.Name]
+	if !ok {
+		ttl = c.DefaultTTL
+	}
+	if ttl <= 0 {
+		return send(call)
+	}
+	key := cacheKey(call)
+	entry, found := c.Cache.Get(key)
+	if found && time.Now().Before(entry.Expires) {
+		call.Cached = true
+		return entry.Response
+	}
+	if found {
+		if entry.ETag != "" {
+			call.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			call.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	response := send(call)
+	if found && call.StatusCode == 304 {
+		entry.Expires = time.Now().Add(ttl)
+		c.Cache.Set(key, entry)
+		call.Response = entry.Response
+		return entry.Response
+	}
+	if call.Err == nil && (call.StatusCode == 200 || call.noRedirect && call.StatusCode >= 300 && call.StatusCode < 400) {
+		c.Cache.Set(key, CacheEntry{
+			Response:     response,
+			ETag:         call.ResponseHeader.Get("ETag"),
+			LastModified: call.ResponseHeader.Get("Last-Modified"),
+			Blog:         call.Blog,
+			Expires:      time.Now().Add(ttl),
+		})
+	}
+	return response
+}
+
+//Returns the key the response of the call is cached under:
+//its endpoint and its parameters without the api_key and the OAuth ones.
+func cacheKey(call *APICall) string {
+	values := url.Values{}
+	for key, value := range call.Params {
+		if key != "api_key" && !strings.HasPrefix(key, "oauth_") {
+			values.Set(key, value)
+		}
+	}
+	return call.Method + " " + call.Endpoint + "?" + values.Encode()
+}
@@ -0,0 +1,147 @@
+package gotumblr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func countingHandler(url, response string, requests *int) {
+	mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		fmt.Fprint(w, response)
+	})
+}
+
+func TestCacheServesFreshResponses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	infoRequests, followersRequests := 0, 0
+	countingHandler("/v2/blog/mgterzieva/info", `{"response": {"blog": {"name": "mgterzieva"}}}`, &infoRequests)
+	countingHandler("/v2/blog/mgterzieva/followers", `{"response": {"total_users": 3}}`, &followersRequests)
+
+	client.SetCache(CacheOptions{Cache: NewLRUCache(10), TTL: map[string]time.Duration{"/v2/blog/{blog}/info": time.Minute}})
+	client.BlogInfo("mgterzieva")
+	info := client.BlogInfo("mgterzieva.tumblr.com").Blog
+	client.Followers("mgterzieva", map[string]string{})
+	client.Followers("mgterzieva", map[string]string{})
+
+	if info.Name != "mgterzieva" {
+		t.Errorf("BlogInfo returned %+v, want the cached blog", info)
+	}
+	if infoRequests != 1 {
+		t.Errorf("info was requested %v times, want 1", infoRequests)
+	}
+	if followersRequests != 2 {
+		t.Errorf("followers were requested %v times, want 2 as they are not cached", followersRequests)
+	}
+}
+
+func TestCacheIgnoresAuthParameters(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	countingHandler("/v2/blog/mgterzieva/posts", `{"response": {"total_posts": 8}}`, &requests)
+
+	client.SetCache(CacheOptions{Cache: NewLRUCache(10), DefaultTTL: time.Minute})
+	client.Posts("mgterzieva", "", map[string]string{"api_key": "first"})
+	client.Posts("mgterzieva", "", map[string]string{"api_key": "second"})
+	client.Posts("mgterzieva", "", map[string]string{"tag": "golang"})
+
+	if requests != 2 {
+		t.Errorf("posts were requested %v times, want 2", requests)
+	}
+}
+
+func TestCacheConditionalRequest(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v2/blog/mgterzieva/info", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"response": {"blog": {"name": "mgterzieva"}}}`)
+	})
+
+	client.SetCache(CacheOptions{Cache: NewLRUCache(10), DefaultTTL: time.Nanosecond})
+	client.BlogInfo("mgterzieva")
+	time.Sleep(time.Millisecond)
+	info := client.BlogInfo("mgterzieva").Blog
+
+	if requests != 2 {
+		t.Errorf("info was requested %v times, want 2", requests)
+	}
+	if info.Name != "mgterzieva" {
+		t.Errorf("BlogInfo returned %+v, want the revalidated blog", info)
+	}
+}
+
+func TestCacheInvalidatedByWrites(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	countingHandler("/v2/blog/mgterzieva/info", `{"response": {"blog": {"name": "mgterzieva"}}}`, &requests)
+	handleFunc("/v2/blog/mgterzieva/post", "POST", `{"meta": {"status": 201, "msg": "Created"}}`, map[string]string{}, t)
+
+	client.SetCache(CacheOptions{Cache: NewLRUCache(10), DefaultTTL: time.Minute})
+	client.BlogInfo("mgterzieva")
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	client.BlogInfo("mgterzieva")
+	client.InvalidateBlog("http://mgterzieva.tumblr.com/")
+	client.BlogInfo("mgterzieva")
+
+	if requests != 3 {
+		t.Errorf("info was requested %v times, want 3", requests)
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", CacheEntry{Blog: "a"})
+	cache.Set("b", CacheEntry{Blog: "b"})
+	cache.Get("a")
+	cache.Set("c", CacheEntry{Blog: "c"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("least recently used entry b was not evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Errorf("recently used entry a was evicted")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %v, want 2", cache.Len())
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned %+v, want %+v", err, nil)
+	}
+	entry := CacheEntry{Response: CompleteResponse{Meta: MetaInfo{Status: 200, Msg: "OK"}}, ETag: `"v1"`, Blog: "mgterzieva"}
+	cache.Set("GET /v2/blog/mgterzieva/info?", entry)
+	cache.Set("GET /v2/user/info?", CacheEntry{})
+
+	reopened, _ := NewDiskCache(dir)
+	got, ok := reopened.Get("GET /v2/blog/mgterzieva/info?")
+	if !ok || got.ETag != entry.ETag || got.Response.Meta != entry.Response.Meta {
+		t.Errorf("Get returned %+v, %v, want %+v", got, ok, entry)
+	}
+	reopened.DeleteBlog("mgterzieva")
+	if _, ok := reopened.Get("GET /v2/blog/mgterzieva/info?"); ok {
+		t.Errorf("DeleteBlog didn't remove the blog's entry")
+	}
+	if _, ok := reopened.Get("GET /v2/user/info?"); !ok {
+		t.Errorf("DeleteBlog removed another entry")
+	}
+}
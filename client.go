@@ -0,0 +1,86 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+//Client is implemented by TumblrRestClient and FakeClient.
+//Depend on it instead of *TumblrRestClient when the code needs to be tested without HTTP.
+//It has the calls of the API; operations built on many calls, such as Bulk, aren't part of it.
+type Client interface {
+	Info() UserInfoResponse
+	UserLimits() UserLimitsResponse
+	FilteredTags() FilteredTagsResponse
+	AddFilteredTags(tags ...string) error
+	RemoveFilteredTag(tag string) error
+	FilteredContent() FilteredContentResponse
+	AddFilteredContent(content ...string) error
+	RemoveFilteredContent(content string) error
+	Avatar(blogname string, size int) AvatarResponse
+	AvatarURL(ctx context.Context, blogname string, size int) (string, error)
+	AvatarImage(ctx context.Context, blogname string, size int) (*AvatarImageResponse, error)
+	Likes(options map[string]string) LikesResponse
+	Following(options map[string]string) FollowingResponse
+	Dashboard(options map[string]string) DraftsResponse
+	Tagged(tag string, options map[string]string) []json.RawMessage
+	Posts(blogname, postsType string, options map[string]string) PostsResponse
+	BlogInfo(blogname string) BlogInfoResponse
+	Followers(blogname string, options map[string]string) FollowersResponse
+	BlogLikes(blogname string, options map[string]string) LikesResponse
+	BlogFollowing(blogname string, options map[string]string) FollowingResponse
+	FollowedBy(blogname, query string) FollowedByResponse
+	Blocks(blogname string, options map[string]string) BlocksResponse
+	Block(blogname, target string) error
+	BulkBlock(blogname string, targets []string) error
+	Unblock(blogname, target string) error
+	FollowersIterator(ctx context.Context, blogname string) *UserIterator
+	BlogFollowingIterator(ctx context.Context, blogname string) *BlogIterator
+	BlocksIterator(ctx context.Context, blogname string) *BlogIterator
+	PostsIterator(ctx context.Context, blogname, postsType string, options map[string]string) *PostIterator
+	QueueIterator(ctx context.Context, blogname string) *PostIterator
+	DraftsIterator(ctx context.Context, blogname string) *PostIterator
+	SubmissionsIterator(ctx context.Context, blogname string) *PostIterator
+	BlogLikesIterator(ctx context.Context, blogname string) *PostIterator
+	Notifications(ctx context.Context, blogname string, options NotificationOptions) (NotificationsResponse, error)
+	NotificationStream(ctx context.Context, blogname string, since time.Time, interval time.Duration, types ...NotificationType) *NotificationStream
+	Queue(blogname string, options map[string]string) DraftsResponse
+	QueuedPosts(blogname string, options map[string]string) []QueuedPost
+	ReorderQueue(blogname, postId, insertAfter string) error
+	ShuffleQueue(blogname string) error
+	QueueDraft(blogname, id string) error
+	SchedulePost(blogname, id string, publishOn time.Time) error
+	Drafts(blogname string, options map[string]string) DraftsResponse
+	Submission(blogname string, options map[string]string) DraftsResponse
+	PendingSubmissions(blogname string, options map[string]string) []Submission
+	Asks(blogname string, options map[string]string) []Ask
+	AnswerAsk(blogname, id, answer string, options map[string]string) (PostResult, error)
+	PublishSubmission(blogname, id string, options map[string]string) (PostResult, error)
+	DeclineSubmission(blogname, id string) error
+	SendAsk(blogname, question string, anonymous bool) error
+	SubmitPost(blogname, postType string, options map[string]string) (PostResult, error)
+	Follow(blogname string) error
+	Unfollow(blogname string) error
+	Like(id, reblogKey string) error
+	Unlike(id, reblogKey string) error
+	CreatePhoto(blogname string, options map[string]string) (PostResult, error)
+	CreateText(blogname string, options map[string]string) (PostResult, error)
+	CreateQuote(blogname string, options map[string]string) (PostResult, error)
+	CreateLink(blogname string, options map[string]string) (PostResult, error)
+	CreateChatPost(blogname string, options map[string]string) (PostResult, error)
+	CreateAudio(blogname string, options map[string]string) (PostResult, error)
+	CreateVideo(blogname string, options map[string]string) (PostResult, error)
+	Reblog(blogname string, options map[string]string) (PostResult, error)
+	ReblogPost(ctx context.Context, targetBlog string, sourcePost Post, options ReblogOptions) (PostResult, error)
+	DeletePost(blogname, id string) error
+	MutePost(blogname, id string, duration time.Duration) error
+	UnmutePost(blogname, id string) error
+	PinPost(blogname, id string) error
+	UnpinPost(blogname, id string) error
+	EditPost(blogname string, options map[string]string) (PostResult, error)
+	Blog(blogname string) *BlogClient
+}
+
+var _ Client = (*TumblrRestClient)(nil)
+var _ Client = (*FakeClient)(nil)
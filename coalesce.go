@@ -0,0 +1,99 @@
+package gotumblr
+
+import (
+	"context"
+	"sync"
+)
+
+//Deduplicates identical concurrent calls so that they share one round trip.
+type flightGroup struct {
+	mu        sync.Mutex
+	all       bool
+	endpoints map[string]bool
+	flights   map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	//The context of the call that makes the round trip.
+	ctx    context.Context
+	result APICall
+}
+
+//Turns coalescing of identical concurrent GET requests on or off.
+//While a GET request is in flight, identical requests (same endpoint and parameters,
+//excluding authentication) wait for it and share its response instead of making their own round trip.
+//enabled: whether to coalesce requests.
+//endpoints: the names of the endpoints to coalesce (e.g. /v2/blog/{blog}/info); none means every GET endpoint.
+func (tr *TumblrRequest) SetCoalescing(enabled bool, endpoints ...string) {
+	if !enabled {
+		tr.coalescing = nil
+		return
+	}
+	group := &flightGroup{all: len(endpoints) == 0, endpoints: map[string]bool{}, flights: map[string]*flight{}}
+	for _, endpoint := range endpoints {
+		group.endpoints[endpoint] = true
+	}
+	tr.coalescing = group
+}
+
+//Turns coalescing of identical concurrent GET requests on or off. See TumblrRequest.SetCoalescing.
+func (trc *TumblrRestClient) SetCoalescing(enabled bool, endpoints ...string) {
+	trc.request.SetCoalescing(enabled, endpoints...)
+}
+
+//Sends the call, or waits for an identical call in flight and shares its response.
+//A call stops waiting when its context is done. If the call in flight fails because its own context is done,
+//the calls waiting for it don't share its error; one of them sends its request instead.
+func (g *flightGroup) do(call *APICall, send Handler) CompleteResponse {
+	if call.Method != "GET" || !(g.all || g.endpoints[call.Name]) {
+		return send(call)
+	}
+	key := cacheKey(call)
+	for {
+		g.mu.Lock()
+		f, ok := g.flights[key]
+		if !ok {
+			break
+		}
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+		case <-call.Context.Done():
+			call.Err = call.Context.Err()
+			call.Response = CompleteResponse{Meta: MetaInfo{Msg: call.Err.Error()}}
+			return call.Response
+		}
+		if f.result.Err != nil && f.ctx.Err() != nil {
+			continue
+		}
+		call.Response = f.result.Response
+		call.StatusCode = f.result.StatusCode
+		call.ResponseHeader = f.result.ResponseHeader
+		call.Err = f.result.Err
+		call.Start = f.result.Start
+		call.Duration = f.result.Duration
+		call.Coalesced = true
+		return call.Response
+	}
+	f := &flight{done: make(chan struct{}), ctx: call.Context}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+	response := send(call)
+	f.result = APICall{
+		Response:       response,
+		StatusCode:     call.StatusCode,
+		ResponseHeader: call.ResponseHeader,
+		Err:            call.Err,
+		Start:          call.Start,
+		Duration:       call.Duration,
+	}
+	return response
+}
@@ -0,0 +1,164 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+//A transport that holds the first request until it is released or its context is done
+//and answers the other requests right away.
+type blockingTransport struct {
+	requests int32
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func newBlockingTransport() *blockingTransport {
+	return &blockingTransport{started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (b *blockingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&b.requests, 1) == 1 {
+		close(b.started)
+		select {
+		case <-b.release:
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"response": {"blog": {"name": "mgterzieva"}}}`)),
+		Request:    request,
+	}, nil
+}
+
+//A context that reports when a call starts waiting on it.
+type waitingContext struct {
+	context.Context
+	once    sync.Once
+	waiting chan struct{}
+}
+
+func (c *waitingContext) Done() <-chan struct{} {
+	c.once.Do(func() { c.waiting <- struct{}{} })
+	return c.Context.Done()
+}
+
+func TestSetCoalescing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	transport := newBlockingTransport()
+	client.SetTransport(transport)
+	var coalesced int32
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			response := next(call)
+			if call.Coalesced {
+				atomic.AddInt32(&coalesced, 1)
+			}
+			return response
+		}
+	})
+	client.SetCoalescing(true, "/v2/blog/{blog}/info")
+
+	var wg sync.WaitGroup
+	names := make([]string, 5)
+	info := func(i int, ctx context.Context) {
+		defer wg.Done()
+		var result BlogInfoResponse
+		json.Unmarshal(client.request.GetContext(ctx, "/v2/blog/mgterzieva/info", map[string]string{}).Response, &result)
+		names[i] = result.Blog.Name
+	}
+	wg.Add(1)
+	go info(0, context.Background())
+	<-transport.started
+	waiting := make(chan struct{})
+	for i := 1; i < len(names); i++ {
+		wg.Add(1)
+		go info(i, &waitingContext{Context: context.Background(), waiting: waiting})
+	}
+	for i := 1; i < len(names); i++ {
+		<-waiting
+	}
+	close(transport.release)
+	wg.Wait()
+
+	if transport.requests != 1 {
+		t.Errorf("info was requested %v times, want 1", transport.requests)
+	}
+	if coalesced != int32(len(names)-1) {
+		t.Errorf("%v calls were coalesced, want %v", coalesced, len(names)-1)
+	}
+	for _, name := range names {
+		if name != "mgterzieva" {
+			t.Errorf("BlogInfo returned %v, want mgterzieva", name)
+		}
+	}
+}
+
+func TestSetCoalescingCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	transport := newBlockingTransport()
+	client.SetTransport(transport)
+	client.SetCoalescing(true)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := client.AvatarURL(leaderCtx, "mgterzieva", 64)
+		leader <- err
+	}()
+	<-transport.started
+
+	//A follower whose context is done stops waiting.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.AvatarURL(ctx, "mgterzieva", 64); err != context.Canceled {
+		t.Errorf("the canceled follower returned %v, want %v", err, context.Canceled)
+	}
+
+	//A follower of a leader whose context is done sends its own request.
+	waiting := make(chan struct{})
+	follower := make(chan error)
+	go func() {
+		_, err := client.AvatarURL(&waitingContext{Context: context.Background(), waiting: waiting}, "mgterzieva", 64)
+		follower <- err
+	}()
+	<-waiting
+	cancelLeader()
+	if err := <-leader; err == nil {
+		t.Errorf("the canceled leader returned no error")
+	}
+	if err := <-follower; err != nil {
+		t.Errorf("the follower returned %v, want no error", err)
+	}
+	if transport.requests != 2 {
+		t.Errorf("the avatar was requested %v times, want 2", transport.requests)
+	}
+}
+
+func TestSetCoalescingOtherEndpoints(t *testing.T) {
+	setup()
+	defer teardown()
+
+	transport := newBlockingTransport()
+	close(transport.release)
+	client.SetTransport(transport)
+	client.SetCoalescing(true, "/v2/blog/{blog}/info")
+	client.Followers("mgterzieva", map[string]string{})
+	client.Followers("mgterzieva", map[string]string{})
+	if transport.requests != 2 {
+		t.Errorf("followers were requested %v times, want 2", transport.requests)
+	}
+}
@@ -0,0 +1,30 @@
+package gotumblr
+
+import "strconv"
+
+//A category of content that a community label warns about.
+//The community label of a post is sent as the has_community_label and community_label_categories options
+//of Create* and EditPost, which SetCommunityLabel and RemoveCommunityLabel set.
+type CommunityLabelCategory string
+
+const (
+	CommunityLabelDrugUse      CommunityLabelCategory = "drug_use"
+	CommunityLabelViolence     CommunityLabelCategory = "violence"
+	CommunityLabelSexualThemes CommunityLabelCategory = "sexual_themes"
+)
+
+//Sets the options of a Create* or EditPost call that label the post as mature content:
+//has_community_label and the community_label_categories, indexed from 0.
+//options: the options of the call.
+//categories: what the post contains. A label without categories marks the post as mature in general.
+func SetCommunityLabel(options map[string]string, categories ...CommunityLabelCategory) {
+	options["has_community_label"] = "true"
+	for i, category := range categories {
+		options["community_label_categories["+strconv.Itoa(i)+"]"] = string(category)
+	}
+}
+
+//Sets the options of an EditPost call that remove the community label of the post.
+func RemoveCommunityLabel(options map[string]string) {
+	options["has_community_label"] = "false"
+}
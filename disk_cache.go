@@ -0,0 +1,98 @@
+package gotumblr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//DiskCache is a Cache that stores every entry as a JSON file in a directory,
+//so that cached responses survive restarts.
+type DiskCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type diskItem struct {
+	Key   string
+	Entry CacheEntry
+}
+
+//Creates a DiskCache that stores its entries in the given directory, creating it if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+//Returns the entry stored under the key.
+func (c *DiskCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, err := c.read(c.path(key))
+	if err != nil || item.Key != key {
+		return CacheEntry{}, false
+	}
+	return item.Entry, true
+}
+
+//Stores the entry under the key.
+func (c *DiskCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	content, err := json.Marshal(diskItem{key, entry})
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	if ioutil.WriteFile(path+".tmp", content, 0644) == nil {
+		os.Rename(path+".tmp", path)
+	}
+}
+
+//Removes the entry stored under the key.
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	os.Remove(c.path(key))
+}
+
+//Removes all entries about the given blog.
+func (c *DiskCache) DeleteBlog(blog string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.dir, file.Name())
+		if item, err := c.read(path); err == nil && item.Entry.Blog == blog {
+			os.Remove(path)
+		}
+	}
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *DiskCache) read(path string) (diskItem, error) {
+	var item diskItem
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return item, err
+	}
+	err = json.Unmarshal(content, &item)
+	return item, err
+}
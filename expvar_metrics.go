@@ -0,0 +1,212 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//The default latency histogram buckets of ExpvarMetrics, in seconds.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+//ExpvarMetrics is a Metrics implementation that keeps per-endpoint latency histograms,
+//error counters and remaining quota gauges in memory.
+//It is published as an expvar variable and serves the Prometheus text exposition format as an http.Handler.
+type ExpvarMetrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	latency map[endpointKey]*histogram
+	errors  map[errorKey]int64
+	quota   map[string]int64
+}
+
+type endpointKey struct {
+	method, endpoint string
+}
+
+type errorKey struct {
+	method, endpoint string
+	status           int
+}
+
+type histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+//Guards publishing ExpvarMetrics, so that two of them aren't published with the same name at once.
+var expvarMu sync.Mutex
+
+//Creates an ExpvarMetrics and publishes it as the expvar variable with the given name.
+//If an ExpvarMetrics is published with the name already, it is returned instead, so clients created with
+//the same name share their metrics; if another variable has the name, the metrics aren't published.
+//name: the name of the expvar variable; an empty name doesn't publish it.
+//buckets: the upper bounds of the latency histogram buckets in seconds; none means DefaultLatencyBuckets.
+func NewExpvarMetrics(name string, buckets ...float64) *ExpvarMetrics {
+	if name != "" {
+		expvarMu.Lock()
+		defer expvarMu.Unlock()
+		if published, ok := expvar.Get(name).(*ExpvarMetrics); ok {
+			return published
+		}
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	m := &ExpvarMetrics{
+		buckets: buckets,
+		latency: map[endpointKey]*histogram{},
+		errors:  map[errorKey]int64{},
+		quota:   map[string]int64{},
+	}
+	if name != "" && expvar.Get(name) == nil {
+		expvar.Publish(name, m)
+	}
+	return m
+}
+
+//Records how long a call to the endpoint took.
+func (m *ExpvarMetrics) ObserveLatency(method, endpoint string, status int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := endpointKey{method, endpoint}
+	h, ok := m.latency[key]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(m.buckets))}
+		m.latency[key] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+//Counts a call to the endpoint that failed.
+func (m *ExpvarMetrics) CountError(method, endpoint string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[errorKey{method, endpoint, status}]++
+}
+
+//Records the number of calls remaining in a rate limit window.
+func (m *ExpvarMetrics) SetQuotaRemaining(window string, remaining int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quota[window] = remaining
+}
+
+//Returns the metrics as JSON, so that ExpvarMetrics is an expvar.Var.
+func (m *ExpvarMetrics) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	type latency struct {
+		Method, Endpoint string
+		Count            int64
+		Sum              float64
+		Buckets          map[string]int64
+	}
+	type errorCount struct {
+		Method, Endpoint string
+		Status           int
+		Count            int64
+	}
+	snapshot := struct {
+		Latency []latency
+		Errors  []errorCount
+		Quota   map[string]int64
+	}{[]latency{}, []errorCount{}, m.quota}
+	for _, key := range m.latencyKeys() {
+		h := m.latency[key]
+		buckets := map[string]int64{}
+		for i, bound := range m.buckets {
+			buckets[formatFloat(bound)] = h.counts[i]
+		}
+		snapshot.Latency = append(snapshot.Latency, latency{key.method, key.endpoint, h.count, h.sum, buckets})
+	}
+	for _, key := range m.errorKeys() {
+		snapshot.Errors = append(snapshot.Errors, errorCount{key.method, key.endpoint, key.status, m.errors[key]})
+	}
+	content, _ := json.Marshal(snapshot)
+	return string(content)
+}
+
+//Serves the metrics in the Prometheus text exposition format.
+func (m *ExpvarMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# HELP gotumblr_request_duration_seconds Latency of Tumblr API calls.")
+	fmt.Fprintln(w, "# TYPE gotumblr_request_duration_seconds histogram")
+	for _, key := range m.latencyKeys() {
+		h := m.latency[key]
+		labels := fmt.Sprintf("method=%q,endpoint=%q", key.method, key.endpoint)
+		for i, bound := range m.buckets {
+			fmt.Fprintf(w, "gotumblr_request_duration_seconds_bucket{%s,le=%q} %d\n", labels, formatFloat(bound), h.counts[i])
+		}
+		fmt.Fprintf(w, "gotumblr_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(w, "gotumblr_request_duration_seconds_sum{%s} %s\n", labels, formatFloat(h.sum))
+		fmt.Fprintf(w, "gotumblr_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+	fmt.Fprintln(w, "# HELP gotumblr_request_errors_total Failed Tumblr API calls.")
+	fmt.Fprintln(w, "# TYPE gotumblr_request_errors_total counter")
+	for _, key := range m.errorKeys() {
+		fmt.Fprintf(w, "gotumblr_request_errors_total{method=%q,endpoint=%q,status=\"%d\"} %d\n", key.method, key.endpoint, key.status, m.errors[key])
+	}
+	fmt.Fprintln(w, "# HELP gotumblr_ratelimit_remaining Tumblr API calls remaining in the rate limit window.")
+	fmt.Fprintln(w, "# TYPE gotumblr_ratelimit_remaining gauge")
+	windows := make([]string, 0, len(m.quota))
+	for window := range m.quota {
+		windows = append(windows, window)
+	}
+	sort.Strings(windows)
+	for _, window := range windows {
+		fmt.Fprintf(w, "gotumblr_ratelimit_remaining{window=%q} %d\n", window, m.quota[window])
+	}
+}
+
+func (m *ExpvarMetrics) latencyKeys() []endpointKey {
+	keys := make([]endpointKey, 0, len(m.latency))
+	for key := range m.latency {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+	return keys
+}
+
+func (m *ExpvarMetrics) errorKeys() []errorKey {
+	keys := make([]errorKey, 0, len(m.errors))
+	for key := range m.errors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,51 @@
+package gotumblr
+
+//go:generate go run ./internal/genfake
+
+//A call made to a FakeClient.
+type FakeCall struct {
+	Method string
+	Args   []interface{}
+}
+
+//Returns all calls made to the fake in the order they were made.
+func (f *FakeClient) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]FakeCall, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+//Returns the calls made to the given method in the order they were made.
+//method: the name of the method (e.g. CreateText).
+func (f *FakeClient) CallsTo(method string) []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := []FakeCall{}
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+//Forgets all recorded calls.
+func (f *FakeClient) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+func (f *FakeClient) record(method string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, FakeCall{method, args})
+}
+
+//Records the call and returns a BlogClient backed by the fake.
+func (f *FakeClient) Blog(blogname string) *BlogClient {
+	f.record("Blog", blogname)
+	return &BlogClient{f, NormalizeBlogIdentifier(blogname)}
+}
@@ -0,0 +1,757 @@
+// Code generated by internal/genfake from client.go. DO NOT EDIT.
+
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+//FakeClient is a configurable Client that doesn't make any HTTP requests.
+//Set the <Method>Func field of the method you want to stub;
+//methods whose function is nil return the zero value of their results.
+//Every call is recorded and can be inspected with Calls and CallsTo.
+type FakeClient struct {
+	mu    sync.Mutex
+	calls []FakeCall
+
+	InfoFunc                  func() UserInfoResponse
+	UserLimitsFunc            func() UserLimitsResponse
+	FilteredTagsFunc          func() FilteredTagsResponse
+	AddFilteredTagsFunc       func(tags ...string) error
+	RemoveFilteredTagFunc     func(tag string) error
+	FilteredContentFunc       func() FilteredContentResponse
+	AddFilteredContentFunc    func(content ...string) error
+	RemoveFilteredContentFunc func(content string) error
+	AvatarFunc                func(blogname string, size int) AvatarResponse
+	AvatarURLFunc             func(ctx context.Context, blogname string, size int) (string, error)
+	AvatarImageFunc           func(ctx context.Context, blogname string, size int) (*AvatarImageResponse, error)
+	LikesFunc                 func(options map[string]string) LikesResponse
+	FollowingFunc             func(options map[string]string) FollowingResponse
+	DashboardFunc             func(options map[string]string) DraftsResponse
+	TaggedFunc                func(tag string, options map[string]string) []json.RawMessage
+	PostsFunc                 func(blogname, postsType string, options map[string]string) PostsResponse
+	BlogInfoFunc              func(blogname string) BlogInfoResponse
+	FollowersFunc             func(blogname string, options map[string]string) FollowersResponse
+	BlogLikesFunc             func(blogname string, options map[string]string) LikesResponse
+	BlogFollowingFunc         func(blogname string, options map[string]string) FollowingResponse
+	FollowedByFunc            func(blogname, query string) FollowedByResponse
+	BlocksFunc                func(blogname string, options map[string]string) BlocksResponse
+	BlockFunc                 func(blogname, target string) error
+	BulkBlockFunc             func(blogname string, targets []string) error
+	UnblockFunc               func(blogname, target string) error
+	FollowersIteratorFunc     func(ctx context.Context, blogname string) *UserIterator
+	BlogFollowingIteratorFunc func(ctx context.Context, blogname string) *BlogIterator
+	BlocksIteratorFunc        func(ctx context.Context, blogname string) *BlogIterator
+	PostsIteratorFunc         func(ctx context.Context, blogname, postsType string, options map[string]string) *PostIterator
+	QueueIteratorFunc         func(ctx context.Context, blogname string) *PostIterator
+	DraftsIteratorFunc        func(ctx context.Context, blogname string) *PostIterator
+	SubmissionsIteratorFunc   func(ctx context.Context, blogname string) *PostIterator
+	BlogLikesIteratorFunc     func(ctx context.Context, blogname string) *PostIterator
+	NotificationsFunc         func(ctx context.Context, blogname string, options NotificationOptions) (NotificationsResponse, error)
+	NotificationStreamFunc    func(ctx context.Context, blogname string, since time.Time, interval time.Duration, types ...NotificationType) *NotificationStream
+	QueueFunc                 func(blogname string, options map[string]string) DraftsResponse
+	QueuedPostsFunc           func(blogname string, options map[string]string) []QueuedPost
+	ReorderQueueFunc          func(blogname, postId, insertAfter string) error
+	ShuffleQueueFunc          func(blogname string) error
+	QueueDraftFunc            func(blogname, id string) error
+	SchedulePostFunc          func(blogname, id string, publishOn time.Time) error
+	DraftsFunc                func(blogname string, options map[string]string) DraftsResponse
+	SubmissionFunc            func(blogname string, options map[string]string) DraftsResponse
+	PendingSubmissionsFunc    func(blogname string, options map[string]string) []Submission
+	AsksFunc                  func(blogname string, options map[string]string) []Ask
+	AnswerAskFunc             func(blogname, id, answer string, options map[string]string) (PostResult, error)
+	PublishSubmissionFunc     func(blogname, id string, options map[string]string) (PostResult, error)
+	DeclineSubmissionFunc     func(blogname, id string) error
+	SendAskFunc               func(blogname, question string, anonymous bool) error
+	SubmitPostFunc            func(blogname, postType string, options map[string]string) (PostResult, error)
+	FollowFunc                func(blogname string) error
+	UnfollowFunc              func(blogname string) error
+	LikeFunc                  func(id, reblogKey string) error
+	UnlikeFunc                func(id, reblogKey string) error
+	CreatePhotoFunc           func(blogname string, options map[string]string) (PostResult, error)
+	CreateTextFunc            func(blogname string, options map[string]string) (PostResult, error)
+	CreateQuoteFunc           func(blogname string, options map[string]string) (PostResult, error)
+	CreateLinkFunc            func(blogname string, options map[string]string) (PostResult, error)
+	CreateChatPostFunc        func(blogname string, options map[string]string) (PostResult, error)
+	CreateAudioFunc           func(blogname string, options map[string]string) (PostResult, error)
+	CreateVideoFunc           func(blogname string, options map[string]string) (PostResult, error)
+	ReblogFunc                func(blogname string, options map[string]string) (PostResult, error)
+	ReblogPostFunc            func(ctx context.Context, targetBlog string, sourcePost Post, options ReblogOptions) (PostResult, error)
+	DeletePostFunc            func(blogname, id string) error
+	MutePostFunc              func(blogname, id string, duration time.Duration) error
+	UnmutePostFunc            func(blogname, id string) error
+	PinPostFunc               func(blogname, id string) error
+	UnpinPostFunc             func(blogname, id string) error
+	EditPostFunc              func(blogname string, options map[string]string) (PostResult, error)
+}
+
+//Records the call and returns the result of InfoFunc.
+func (f *FakeClient) Info() UserInfoResponse {
+	f.record("Info")
+	if f.InfoFunc != nil {
+		return f.InfoFunc()
+	}
+	var result UserInfoResponse
+	return result
+}
+
+//Records the call and returns the result of UserLimitsFunc.
+func (f *FakeClient) UserLimits() UserLimitsResponse {
+	f.record("UserLimits")
+	if f.UserLimitsFunc != nil {
+		return f.UserLimitsFunc()
+	}
+	var result UserLimitsResponse
+	return result
+}
+
+//Records the call and returns the result of FilteredTagsFunc.
+func (f *FakeClient) FilteredTags() FilteredTagsResponse {
+	f.record("FilteredTags")
+	if f.FilteredTagsFunc != nil {
+		return f.FilteredTagsFunc()
+	}
+	var result FilteredTagsResponse
+	return result
+}
+
+//Records the call and returns the result of AddFilteredTagsFunc.
+func (f *FakeClient) AddFilteredTags(tags ...string) error {
+	f.record("AddFilteredTags", tags)
+	if f.AddFilteredTagsFunc != nil {
+		return f.AddFilteredTagsFunc(tags...)
+	}
+	return nil
+}
+
+//Records the call and returns the result of RemoveFilteredTagFunc.
+func (f *FakeClient) RemoveFilteredTag(tag string) error {
+	f.record("RemoveFilteredTag", tag)
+	if f.RemoveFilteredTagFunc != nil {
+		return f.RemoveFilteredTagFunc(tag)
+	}
+	return nil
+}
+
+//Records the call and returns the result of FilteredContentFunc.
+func (f *FakeClient) FilteredContent() FilteredContentResponse {
+	f.record("FilteredContent")
+	if f.FilteredContentFunc != nil {
+		return f.FilteredContentFunc()
+	}
+	var result FilteredContentResponse
+	return result
+}
+
+//Records the call and returns the result of AddFilteredContentFunc.
+func (f *FakeClient) AddFilteredContent(content ...string) error {
+	f.record("AddFilteredContent", content)
+	if f.AddFilteredContentFunc != nil {
+		return f.AddFilteredContentFunc(content...)
+	}
+	return nil
+}
+
+//Records the call and returns the result of RemoveFilteredContentFunc.
+func (f *FakeClient) RemoveFilteredContent(content string) error {
+	f.record("RemoveFilteredContent", content)
+	if f.RemoveFilteredContentFunc != nil {
+		return f.RemoveFilteredContentFunc(content)
+	}
+	return nil
+}
+
+//Records the call and returns the result of AvatarFunc.
+func (f *FakeClient) Avatar(blogname string, size int) AvatarResponse {
+	f.record("Avatar", blogname, size)
+	if f.AvatarFunc != nil {
+		return f.AvatarFunc(blogname, size)
+	}
+	var result AvatarResponse
+	return result
+}
+
+//Records the call and returns the result of AvatarURLFunc.
+func (f *FakeClient) AvatarURL(ctx context.Context, blogname string, size int) (string, error) {
+	f.record("AvatarURL", ctx, blogname, size)
+	if f.AvatarURLFunc != nil {
+		return f.AvatarURLFunc(ctx, blogname, size)
+	}
+	var result string
+	return result, nil
+}
+
+//Records the call and returns the result of AvatarImageFunc.
+func (f *FakeClient) AvatarImage(ctx context.Context, blogname string, size int) (*AvatarImageResponse, error) {
+	f.record("AvatarImage", ctx, blogname, size)
+	if f.AvatarImageFunc != nil {
+		return f.AvatarImageFunc(ctx, blogname, size)
+	}
+	var result *AvatarImageResponse
+	return result, nil
+}
+
+//Records the call and returns the result of LikesFunc.
+func (f *FakeClient) Likes(options map[string]string) LikesResponse {
+	f.record("Likes", options)
+	if f.LikesFunc != nil {
+		return f.LikesFunc(options)
+	}
+	var result LikesResponse
+	return result
+}
+
+//Records the call and returns the result of FollowingFunc.
+func (f *FakeClient) Following(options map[string]string) FollowingResponse {
+	f.record("Following", options)
+	if f.FollowingFunc != nil {
+		return f.FollowingFunc(options)
+	}
+	var result FollowingResponse
+	return result
+}
+
+//Records the call and returns the result of DashboardFunc.
+func (f *FakeClient) Dashboard(options map[string]string) DraftsResponse {
+	f.record("Dashboard", options)
+	if f.DashboardFunc != nil {
+		return f.DashboardFunc(options)
+	}
+	var result DraftsResponse
+	return result
+}
+
+//Records the call and returns the result of TaggedFunc.
+func (f *FakeClient) Tagged(tag string, options map[string]string) []json.RawMessage {
+	f.record("Tagged", tag, options)
+	if f.TaggedFunc != nil {
+		return f.TaggedFunc(tag, options)
+	}
+	var result []json.RawMessage
+	return result
+}
+
+//Records the call and returns the result of PostsFunc.
+func (f *FakeClient) Posts(blogname, postsType string, options map[string]string) PostsResponse {
+	f.record("Posts", blogname, postsType, options)
+	if f.PostsFunc != nil {
+		return f.PostsFunc(blogname, postsType, options)
+	}
+	var result PostsResponse
+	return result
+}
+
+//Records the call and returns the result of BlogInfoFunc.
+func (f *FakeClient) BlogInfo(blogname string) BlogInfoResponse {
+	f.record("BlogInfo", blogname)
+	if f.BlogInfoFunc != nil {
+		return f.BlogInfoFunc(blogname)
+	}
+	var result BlogInfoResponse
+	return result
+}
+
+//Records the call and returns the result of FollowersFunc.
+func (f *FakeClient) Followers(blogname string, options map[string]string) FollowersResponse {
+	f.record("Followers", blogname, options)
+	if f.FollowersFunc != nil {
+		return f.FollowersFunc(blogname, options)
+	}
+	var result FollowersResponse
+	return result
+}
+
+//Records the call and returns the result of BlogLikesFunc.
+func (f *FakeClient) BlogLikes(blogname string, options map[string]string) LikesResponse {
+	f.record("BlogLikes", blogname, options)
+	if f.BlogLikesFunc != nil {
+		return f.BlogLikesFunc(blogname, options)
+	}
+	var result LikesResponse
+	return result
+}
+
+//Records the call and returns the result of BlogFollowingFunc.
+func (f *FakeClient) BlogFollowing(blogname string, options map[string]string) FollowingResponse {
+	f.record("BlogFollowing", blogname, options)
+	if f.BlogFollowingFunc != nil {
+		return f.BlogFollowingFunc(blogname, options)
+	}
+	var result FollowingResponse
+	return result
+}
+
+//Records the call and returns the result of FollowedByFunc.
+func (f *FakeClient) FollowedBy(blogname, query string) FollowedByResponse {
+	f.record("FollowedBy", blogname, query)
+	if f.FollowedByFunc != nil {
+		return f.FollowedByFunc(blogname, query)
+	}
+	var result FollowedByResponse
+	return result
+}
+
+//Records the call and returns the result of BlocksFunc.
+func (f *FakeClient) Blocks(blogname string, options map[string]string) BlocksResponse {
+	f.record("Blocks", blogname, options)
+	if f.BlocksFunc != nil {
+		return f.BlocksFunc(blogname, options)
+	}
+	var result BlocksResponse
+	return result
+}
+
+//Records the call and returns the result of BlockFunc.
+func (f *FakeClient) Block(blogname, target string) error {
+	f.record("Block", blogname, target)
+	if f.BlockFunc != nil {
+		return f.BlockFunc(blogname, target)
+	}
+	return nil
+}
+
+//Records the call and returns the result of BulkBlockFunc.
+func (f *FakeClient) BulkBlock(blogname string, targets []string) error {
+	f.record("BulkBlock", blogname, targets)
+	if f.BulkBlockFunc != nil {
+		return f.BulkBlockFunc(blogname, targets)
+	}
+	return nil
+}
+
+//Records the call and returns the result of UnblockFunc.
+func (f *FakeClient) Unblock(blogname, target string) error {
+	f.record("Unblock", blogname, target)
+	if f.UnblockFunc != nil {
+		return f.UnblockFunc(blogname, target)
+	}
+	return nil
+}
+
+//Records the call and returns the result of FollowersIteratorFunc.
+func (f *FakeClient) FollowersIterator(ctx context.Context, blogname string) *UserIterator {
+	f.record("FollowersIterator", ctx, blogname)
+	if f.FollowersIteratorFunc != nil {
+		return f.FollowersIteratorFunc(ctx, blogname)
+	}
+	var result *UserIterator
+	return result
+}
+
+//Records the call and returns the result of BlogFollowingIteratorFunc.
+func (f *FakeClient) BlogFollowingIterator(ctx context.Context, blogname string) *BlogIterator {
+	f.record("BlogFollowingIterator", ctx, blogname)
+	if f.BlogFollowingIteratorFunc != nil {
+		return f.BlogFollowingIteratorFunc(ctx, blogname)
+	}
+	var result *BlogIterator
+	return result
+}
+
+//Records the call and returns the result of BlocksIteratorFunc.
+func (f *FakeClient) BlocksIterator(ctx context.Context, blogname string) *BlogIterator {
+	f.record("BlocksIterator", ctx, blogname)
+	if f.BlocksIteratorFunc != nil {
+		return f.BlocksIteratorFunc(ctx, blogname)
+	}
+	var result *BlogIterator
+	return result
+}
+
+//Records the call and returns the result of PostsIteratorFunc.
+func (f *FakeClient) PostsIterator(ctx context.Context, blogname, postsType string, options map[string]string) *PostIterator {
+	f.record("PostsIterator", ctx, blogname, postsType, options)
+	if f.PostsIteratorFunc != nil {
+		return f.PostsIteratorFunc(ctx, blogname, postsType, options)
+	}
+	var result *PostIterator
+	return result
+}
+
+//Records the call and returns the result of QueueIteratorFunc.
+func (f *FakeClient) QueueIterator(ctx context.Context, blogname string) *PostIterator {
+	f.record("QueueIterator", ctx, blogname)
+	if f.QueueIteratorFunc != nil {
+		return f.QueueIteratorFunc(ctx, blogname)
+	}
+	var result *PostIterator
+	return result
+}
+
+//Records the call and returns the result of DraftsIteratorFunc.
+func (f *FakeClient) DraftsIterator(ctx context.Context, blogname string) *PostIterator {
+	f.record("DraftsIterator", ctx, blogname)
+	if f.DraftsIteratorFunc != nil {
+		return f.DraftsIteratorFunc(ctx, blogname)
+	}
+	var result *PostIterator
+	return result
+}
+
+//Records the call and returns the result of SubmissionsIteratorFunc.
+func (f *FakeClient) SubmissionsIterator(ctx context.Context, blogname string) *PostIterator {
+	f.record("SubmissionsIterator", ctx, blogname)
+	if f.SubmissionsIteratorFunc != nil {
+		return f.SubmissionsIteratorFunc(ctx, blogname)
+	}
+	var result *PostIterator
+	return result
+}
+
+//Records the call and returns the result of BlogLikesIteratorFunc.
+func (f *FakeClient) BlogLikesIterator(ctx context.Context, blogname string) *PostIterator {
+	f.record("BlogLikesIterator", ctx, blogname)
+	if f.BlogLikesIteratorFunc != nil {
+		return f.BlogLikesIteratorFunc(ctx, blogname)
+	}
+	var result *PostIterator
+	return result
+}
+
+//Records the call and returns the result of NotificationsFunc.
+func (f *FakeClient) Notifications(ctx context.Context, blogname string, options NotificationOptions) (NotificationsResponse, error) {
+	f.record("Notifications", ctx, blogname, options)
+	if f.NotificationsFunc != nil {
+		return f.NotificationsFunc(ctx, blogname, options)
+	}
+	var result NotificationsResponse
+	return result, nil
+}
+
+//Records the call and returns the result of NotificationStreamFunc.
+func (f *FakeClient) NotificationStream(ctx context.Context, blogname string, since time.Time, interval time.Duration, types ...NotificationType) *NotificationStream {
+	f.record("NotificationStream", ctx, blogname, since, interval, types)
+	if f.NotificationStreamFunc != nil {
+		return f.NotificationStreamFunc(ctx, blogname, since, interval, types...)
+	}
+	var result *NotificationStream
+	return result
+}
+
+//Records the call and returns the result of QueueFunc.
+func (f *FakeClient) Queue(blogname string, options map[string]string) DraftsResponse {
+	f.record("Queue", blogname, options)
+	if f.QueueFunc != nil {
+		return f.QueueFunc(blogname, options)
+	}
+	var result DraftsResponse
+	return result
+}
+
+//Records the call and returns the result of QueuedPostsFunc.
+func (f *FakeClient) QueuedPosts(blogname string, options map[string]string) []QueuedPost {
+	f.record("QueuedPosts", blogname, options)
+	if f.QueuedPostsFunc != nil {
+		return f.QueuedPostsFunc(blogname, options)
+	}
+	var result []QueuedPost
+	return result
+}
+
+//Records the call and returns the result of ReorderQueueFunc.
+func (f *FakeClient) ReorderQueue(blogname, postId, insertAfter string) error {
+	f.record("ReorderQueue", blogname, postId, insertAfter)
+	if f.ReorderQueueFunc != nil {
+		return f.ReorderQueueFunc(blogname, postId, insertAfter)
+	}
+	return nil
+}
+
+//Records the call and returns the result of ShuffleQueueFunc.
+func (f *FakeClient) ShuffleQueue(blogname string) error {
+	f.record("ShuffleQueue", blogname)
+	if f.ShuffleQueueFunc != nil {
+		return f.ShuffleQueueFunc(blogname)
+	}
+	return nil
+}
+
+//Records the call and returns the result of QueueDraftFunc.
+func (f *FakeClient) QueueDraft(blogname, id string) error {
+	f.record("QueueDraft", blogname, id)
+	if f.QueueDraftFunc != nil {
+		return f.QueueDraftFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of SchedulePostFunc.
+func (f *FakeClient) SchedulePost(blogname, id string, publishOn time.Time) error {
+	f.record("SchedulePost", blogname, id, publishOn)
+	if f.SchedulePostFunc != nil {
+		return f.SchedulePostFunc(blogname, id, publishOn)
+	}
+	return nil
+}
+
+//Records the call and returns the result of DraftsFunc.
+func (f *FakeClient) Drafts(blogname string, options map[string]string) DraftsResponse {
+	f.record("Drafts", blogname, options)
+	if f.DraftsFunc != nil {
+		return f.DraftsFunc(blogname, options)
+	}
+	var result DraftsResponse
+	return result
+}
+
+//Records the call and returns the result of SubmissionFunc.
+func (f *FakeClient) Submission(blogname string, options map[string]string) DraftsResponse {
+	f.record("Submission", blogname, options)
+	if f.SubmissionFunc != nil {
+		return f.SubmissionFunc(blogname, options)
+	}
+	var result DraftsResponse
+	return result
+}
+
+//Records the call and returns the result of PendingSubmissionsFunc.
+func (f *FakeClient) PendingSubmissions(blogname string, options map[string]string) []Submission {
+	f.record("PendingSubmissions", blogname, options)
+	if f.PendingSubmissionsFunc != nil {
+		return f.PendingSubmissionsFunc(blogname, options)
+	}
+	var result []Submission
+	return result
+}
+
+//Records the call and returns the result of AsksFunc.
+func (f *FakeClient) Asks(blogname string, options map[string]string) []Ask {
+	f.record("Asks", blogname, options)
+	if f.AsksFunc != nil {
+		return f.AsksFunc(blogname, options)
+	}
+	var result []Ask
+	return result
+}
+
+//Records the call and returns the result of AnswerAskFunc.
+func (f *FakeClient) AnswerAsk(blogname, id, answer string, options map[string]string) (PostResult, error) {
+	f.record("AnswerAsk", blogname, id, answer, options)
+	if f.AnswerAskFunc != nil {
+		return f.AnswerAskFunc(blogname, id, answer, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of PublishSubmissionFunc.
+func (f *FakeClient) PublishSubmission(blogname, id string, options map[string]string) (PostResult, error) {
+	f.record("PublishSubmission", blogname, id, options)
+	if f.PublishSubmissionFunc != nil {
+		return f.PublishSubmissionFunc(blogname, id, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of DeclineSubmissionFunc.
+func (f *FakeClient) DeclineSubmission(blogname, id string) error {
+	f.record("DeclineSubmission", blogname, id)
+	if f.DeclineSubmissionFunc != nil {
+		return f.DeclineSubmissionFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of SendAskFunc.
+func (f *FakeClient) SendAsk(blogname, question string, anonymous bool) error {
+	f.record("SendAsk", blogname, question, anonymous)
+	if f.SendAskFunc != nil {
+		return f.SendAskFunc(blogname, question, anonymous)
+	}
+	return nil
+}
+
+//Records the call and returns the result of SubmitPostFunc.
+func (f *FakeClient) SubmitPost(blogname, postType string, options map[string]string) (PostResult, error) {
+	f.record("SubmitPost", blogname, postType, options)
+	if f.SubmitPostFunc != nil {
+		return f.SubmitPostFunc(blogname, postType, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of FollowFunc.
+func (f *FakeClient) Follow(blogname string) error {
+	f.record("Follow", blogname)
+	if f.FollowFunc != nil {
+		return f.FollowFunc(blogname)
+	}
+	return nil
+}
+
+//Records the call and returns the result of UnfollowFunc.
+func (f *FakeClient) Unfollow(blogname string) error {
+	f.record("Unfollow", blogname)
+	if f.UnfollowFunc != nil {
+		return f.UnfollowFunc(blogname)
+	}
+	return nil
+}
+
+//Records the call and returns the result of LikeFunc.
+func (f *FakeClient) Like(id, reblogKey string) error {
+	f.record("Like", id, reblogKey)
+	if f.LikeFunc != nil {
+		return f.LikeFunc(id, reblogKey)
+	}
+	return nil
+}
+
+//Records the call and returns the result of UnlikeFunc.
+func (f *FakeClient) Unlike(id, reblogKey string) error {
+	f.record("Unlike", id, reblogKey)
+	if f.UnlikeFunc != nil {
+		return f.UnlikeFunc(id, reblogKey)
+	}
+	return nil
+}
+
+//Records the call and returns the result of CreatePhotoFunc.
+func (f *FakeClient) CreatePhoto(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreatePhoto", blogname, options)
+	if f.CreatePhotoFunc != nil {
+		return f.CreatePhotoFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateTextFunc.
+func (f *FakeClient) CreateText(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateText", blogname, options)
+	if f.CreateTextFunc != nil {
+		return f.CreateTextFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateQuoteFunc.
+func (f *FakeClient) CreateQuote(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateQuote", blogname, options)
+	if f.CreateQuoteFunc != nil {
+		return f.CreateQuoteFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateLinkFunc.
+func (f *FakeClient) CreateLink(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateLink", blogname, options)
+	if f.CreateLinkFunc != nil {
+		return f.CreateLinkFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateChatPostFunc.
+func (f *FakeClient) CreateChatPost(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateChatPost", blogname, options)
+	if f.CreateChatPostFunc != nil {
+		return f.CreateChatPostFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateAudioFunc.
+func (f *FakeClient) CreateAudio(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateAudio", blogname, options)
+	if f.CreateAudioFunc != nil {
+		return f.CreateAudioFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of CreateVideoFunc.
+func (f *FakeClient) CreateVideo(blogname string, options map[string]string) (PostResult, error) {
+	f.record("CreateVideo", blogname, options)
+	if f.CreateVideoFunc != nil {
+		return f.CreateVideoFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of ReblogFunc.
+func (f *FakeClient) Reblog(blogname string, options map[string]string) (PostResult, error) {
+	f.record("Reblog", blogname, options)
+	if f.ReblogFunc != nil {
+		return f.ReblogFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of ReblogPostFunc.
+func (f *FakeClient) ReblogPost(ctx context.Context, targetBlog string, sourcePost Post, options ReblogOptions) (PostResult, error) {
+	f.record("ReblogPost", ctx, targetBlog, sourcePost, options)
+	if f.ReblogPostFunc != nil {
+		return f.ReblogPostFunc(ctx, targetBlog, sourcePost, options)
+	}
+	var result PostResult
+	return result, nil
+}
+
+//Records the call and returns the result of DeletePostFunc.
+func (f *FakeClient) DeletePost(blogname, id string) error {
+	f.record("DeletePost", blogname, id)
+	if f.DeletePostFunc != nil {
+		return f.DeletePostFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of MutePostFunc.
+func (f *FakeClient) MutePost(blogname, id string, duration time.Duration) error {
+	f.record("MutePost", blogname, id, duration)
+	if f.MutePostFunc != nil {
+		return f.MutePostFunc(blogname, id, duration)
+	}
+	return nil
+}
+
+//Records the call and returns the result of UnmutePostFunc.
+func (f *FakeClient) UnmutePost(blogname, id string) error {
+	f.record("UnmutePost", blogname, id)
+	if f.UnmutePostFunc != nil {
+		return f.UnmutePostFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of PinPostFunc.
+func (f *FakeClient) PinPost(blogname, id string) error {
+	f.record("PinPost", blogname, id)
+	if f.PinPostFunc != nil {
+		return f.PinPostFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of UnpinPostFunc.
+func (f *FakeClient) UnpinPost(blogname, id string) error {
+	f.record("UnpinPost", blogname, id)
+	if f.UnpinPostFunc != nil {
+		return f.UnpinPostFunc(blogname, id)
+	}
+	return nil
+}
+
+//Records the call and returns the result of EditPostFunc.
+func (f *FakeClient) EditPost(blogname string, options map[string]string) (PostResult, error) {
+	f.record("EditPost", blogname, options)
+	if f.EditPostFunc != nil {
+		return f.EditPostFunc(blogname, options)
+	}
+	var result PostResult
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFakeClientDefaults(t *testing.T) {
+	fake := &FakeClient{}
+
+	info := fake.Info()
+	if !reflect.DeepEqual(info, UserInfoResponse{}) {
+		t.Errorf("Info returned %+v, want %+v", info, UserInfoResponse{})
+	}
+	if err := fake.Follow("thehungergames"); err != nil {
+		t.Errorf("Follow returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestFakeClientFunc(t *testing.T) {
+	fake := &FakeClient{
+		BlogInfoFunc: func(blogname string) BlogInfoResponse {
+			return BlogInfoResponse{BlogInfo{Name: blogname}}
+		},
+		CreateTextFunc: func(blogname string, options map[string]string) (PostResult, error) {
+			return PostResult{}, errors.New("Bad Request")
+		},
+	}
+
+	info := fake.BlogInfo("mgterzieva").Blog
+	want := BlogInfo{Name: "mgterzieva"}
+	if info != want {
+		t.Errorf("BlogInfo returned %+v, want %+v", info, want)
+	}
+	_, err := fake.CreateText("mgterzieva", map[string]string{})
+	if !reflect.DeepEqual(err, errors.New("Bad Request")) {
+		t.Errorf("CreateText returned %+v, want %+v", err, errors.New("Bad Request"))
+	}
+}
+
+func TestFakeClientCalls(t *testing.T) {
+	fake := &FakeClient{}
+	var c Client = fake
+
+	c.Like("75195127536", "kLXwhQ19")
+	c.Blog("mgterzieva.tumblr.com").CreateText(map[string]string{"body": "Hello, hello!"})
+
+	want := []FakeCall{
+		{"Like", []interface{}{"75195127536", "kLXwhQ19"}},
+		{"Blog", []interface{}{"mgterzieva.tumblr.com"}},
+		{"CreateText", []interface{}{"mgterzieva", map[string]string{"body": "Hello, hello!"}}},
+	}
+	if calls := fake.Calls(); !reflect.DeepEqual(calls, want) {
+		t.Errorf("Calls returned %+v, want %+v", calls, want)
+	}
+	if calls := fake.CallsTo("CreateText"); !reflect.DeepEqual(calls, want[2:]) {
+		t.Errorf("CallsTo returned %+v, want %+v", calls, want[2:])
+	}
+
+	fake.Reset()
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("Calls after Reset returned %+v, want none", calls)
+	}
+}
+
+func TestFakeClientBulk(t *testing.T) {
+	fake := &FakeClient{}
+
+	report, err := fake.Blog("mgterzieva").Bulk(context.Background(), PostSelector{}, BulkAction{Delete: true}, BulkOptions{})
+	if err != nil || len(report.Items) != 0 {
+		t.Errorf("Bulk returned %+v, %+v, want an empty report", report, err)
+	}
+	for _, method := range []string{"PostsIterator", "QueueIterator", "DraftsIterator"} {
+		if calls := fake.CallsTo(method); len(calls) != 1 {
+			t.Errorf("Bulk called %v %v times, want %v", method, len(calls), 1)
+		}
+	}
+}
+
+func TestFakeClientBulkWithPosts(t *testing.T) {
+	fake := &FakeClient{}
+	fake.PostsIteratorFunc = func(ctx context.Context, blogname, postsType string, options map[string]string) *PostIterator {
+		return NewPostIterator(json.RawMessage(`{"id": 1, "state": "published", "tags": ["spam"]}`), json.RawMessage(`{"id": 2, "state": "published", "tags": ["cats"]}`))
+	}
+
+	report, err := fake.Blog("mgterzieva").Bulk(context.Background(), PostSelector{Tag: "spam"}, BulkAction{Delete: true}, BulkOptions{})
+	if err != nil || len(report.Items) != 1 || report.Count(BulkSucceeded) != 1 {
+		t.Errorf("Bulk returned %+v, %+v, want one deleted post", report, err)
+	}
+	want := []FakeCall{{"DeletePost", []interface{}{"mgterzieva", "1"}}}
+	if calls := fake.CallsTo("DeletePost"); !reflect.DeepEqual(calls, want) {
+		t.Errorf("Bulk deleted %+v, want %+v", calls, want)
+	}
+}
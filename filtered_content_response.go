@@ -0,0 +1,5 @@
+package gotumblr
+
+type FilteredContentResponse struct {
+	Filtered_content []string
+}
@@ -0,0 +1,5 @@
+package gotumblr
+
+type FilteredTagsResponse struct {
+	Filtered_tags []string
+}
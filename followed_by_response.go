@@ -0,0 +1,5 @@
+package gotumblr
+
+type FollowedByResponse struct {
+	Followed_by bool
+}
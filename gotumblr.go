@@ -3,11 +3,13 @@
 package gotumblr
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 //defines a Go Client for the Tumblr API.
@@ -25,6 +27,12 @@ func NewTumblrRestClient(consumerKey, consumerSecret, oauthToken, oauthSecret, c
 	return &TumblrRestClient{NewTumblrRequest(consumerKey, consumerSecret, oauthToken, oauthSecret, callbackUrl, host)}
 }
 
+//Sets the http.RoundTripper used to send the requests (e.g. a recording or replaying one).
+//transport: nil means http.DefaultTransport.
+func (trc *TumblrRestClient) SetTransport(transport http.RoundTripper) {
+	trc.request.SetTransport(transport)
+}
+
 //Gets the user information.
 func (trc *TumblrRestClient) Info() UserInfoResponse {
 	data := trc.request.Get("/v2/user/info", map[string]string{})
@@ -33,30 +41,91 @@ func (trc *TumblrRestClient) Info() UserInfoResponse {
 	return result
 }
 
-//Retrieves the url of the blog's avatar.
-//size can be: 16, 24, 30, 40, 48, 64, 96, 128 or 512.
-func (trc *TumblrRestClient) Avatar(blogname string, size int) AvatarResponse {
-	requestUrl := trc.request.host + fmt.Sprintf("/v2/blog/%s/avatar/%d", blogname, size)
-	httpRequest, err := http.NewRequest("GET", requestUrl, nil)
-	if err != nil {
-		fmt.Println(err)
+//Gets the user's remaining daily limits for posts, photos, videos, follows and so on.
+func (trc *TumblrRestClient) UserLimits() UserLimitsResponse {
+	data := trc.request.Get("/v2/user/limits", map[string]string{})
+	var result UserLimitsResponse
+	json.Unmarshal(data.Response, &result)
+	return result
+}
+
+//Gets the tags the user has filtered out of their dashboard and search results.
+func (trc *TumblrRestClient) FilteredTags() FilteredTagsResponse {
+	data := trc.request.Get("/v2/user/filtered_tags", map[string]string{})
+	var result FilteredTagsResponse
+	json.Unmarshal(data.Response, &result)
+	return result
+}
+
+//Adds tags to the user's filtered tags.
+//tags: the tags to filter, without the leading #.
+func (trc *TumblrRestClient) AddFilteredTags(tags ...string) error {
+	params := map[string]string{}
+	for i, tag := range tags {
+		params["filtered_tags["+strconv.Itoa(i)+"]"] = tag
 	}
-	transport := &http.Transport{}
-	httpResponse, err2 := transport.RoundTrip(httpRequest)
-	if err2 != nil {
-		fmt.Println(err2)
+	data := trc.request.Post("/v2/user/filtered_tags", params)
+	if data.Meta.Status != 200 && data.Meta.Status != 201 {
+		return errors.New(data.Meta.Msg)
 	}
-	defer httpResponse.Body.Close()
-	body, err3 := ioutil.ReadAll(httpResponse.Body)
-	if err3 != nil {
-		fmt.Println(err3)
+	return nil
+}
+
+//Removes a tag from the user's filtered tags.
+//tag: the filtered tag to remove.
+func (trc *TumblrRestClient) RemoveFilteredTag(tag string) error {
+	requestUrl := "/v2/user/filtered_tags/" + url.PathEscape(tag)
+	data := trc.request.Delete(requestUrl, map[string]string{})
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
 	}
-	data := trc.request.JSONParse(body)
-	var result AvatarResponse
+	return nil
+}
+
+//Gets the strings the user has filtered out of their dashboard and search results.
+func (trc *TumblrRestClient) FilteredContent() FilteredContentResponse {
+	data := trc.request.Get("/v2/user/filtered_content", map[string]string{})
+	var result FilteredContentResponse
 	json.Unmarshal(data.Response, &result)
 	return result
 }
 
+//Adds strings to the user's filtered content.
+//Posts that contain any of the strings are hidden from the user.
+func (trc *TumblrRestClient) AddFilteredContent(content ...string) error {
+	params := map[string]string{}
+	for i, value := range content {
+		params["filtered_content["+strconv.Itoa(i)+"]"] = value
+	}
+	data := trc.request.Post("/v2/user/filtered_content", params)
+	if data.Meta.Status != 200 && data.Meta.Status != 201 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Removes a string from the user's filtered content.
+//content: the filtered string to remove.
+func (trc *TumblrRestClient) RemoveFilteredContent(content string) error {
+	params := map[string]string{"filtered_content": content}
+	data := trc.request.Delete("/v2/user/filtered_content", params)
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Retrieves the url of the blog's avatar.
+//size can be: 16, 24, 30, 40, 48, 64, 96, 128 or 512.
+//See AvatarURL for a version that reports errors.
+func (trc *TumblrRestClient) Avatar(blogname string, size int) AvatarResponse {
+	avatarUrl, err := trc.AvatarURL(context.Background(), blogname, size)
+	if err != nil {
+		trc.request.logError("gotumblr: avatar request", err)
+	}
+	return AvatarResponse{avatarUrl}
+}
+
 //Gets the likes of the given user.
 //options can be:
 //limit: the number of results to return, inclusive;
@@ -122,9 +191,9 @@ func (trc *TumblrRestClient) Tagged(tag string, options map[string]string) []jso
 func (trc *TumblrRestClient) Posts(blogname, postsType string, options map[string]string) PostsResponse {
 	var requestUrl string
 	if postsType == "" {
-		requestUrl = fmt.Sprintf("/v2/blog/%s/posts", blogname)
+		requestUrl = blogPath(blogname, "/posts")
 	} else {
-		requestUrl = fmt.Sprintf("/v2/blog/%s/posts/%s", blogname, postsType)
+		requestUrl = blogPath(blogname, "/posts/"+postsType)
 	}
 	options["api_key"] = trc.request.apiKey
 	data := trc.request.Get(requestUrl, options)
@@ -136,7 +205,7 @@ func (trc *TumblrRestClient) Posts(blogname, postsType string, options map[strin
 //Gets general information about the blog.
 //blogname: name of the blog you want to get information about(e.g. mgterzieva.tumblr.com).
 func (trc *TumblrRestClient) BlogInfo(blogname string) BlogInfoResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/info", blogname)
+	requestUrl := blogPath(blogname, "/info")
 	options := map[string]string{"api_key": trc.request.apiKey}
 	data := trc.request.Get(requestUrl, options)
 	var result BlogInfoResponse
@@ -150,7 +219,7 @@ func (trc *TumblrRestClient) BlogInfo(blogname string) BlogInfoResponse {
 //limit: the number of results to return, inclusive;
 //offset: result to start at.
 func (trc *TumblrRestClient) Followers(blogname string, options map[string]string) FollowersResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/followers", blogname)
+	requestUrl := blogPath(blogname, "/followers")
 	data := trc.request.Get(requestUrl, options)
 	var result FollowersResponse
 	json.Unmarshal(data.Response, &result)
@@ -163,7 +232,7 @@ func (trc *TumblrRestClient) Followers(blogname string, options map[string]strin
 //limit: how many likes do you want to get;
 //offset: the number of the like you want to start from.
 func (trc *TumblrRestClient) BlogLikes(blogname string, options map[string]string) LikesResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/likes", blogname)
+	requestUrl := blogPath(blogname, "/likes")
 	options["api_key"] = trc.request.apiKey
 	data := trc.request.Get(requestUrl, options)
 	var result LikesResponse
@@ -177,7 +246,7 @@ func (trc *TumblrRestClient) BlogLikes(blogname string, options map[string]strin
 //offset: post number to start at;
 //filter: specify posts' format(e.g. format="html", format="text", format="raw").
 func (trc *TumblrRestClient) Queue(blogname string, options map[string]string) DraftsResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/posts/queue", blogname)
+	requestUrl := blogPath(blogname, "/posts/queue")
 	data := trc.request.Get(requestUrl, options)
 	var result DraftsResponse
 	json.Unmarshal(data.Response, &result)
@@ -188,7 +257,7 @@ func (trc *TumblrRestClient) Queue(blogname string, options map[string]string) D
 //options can be:
 //filter: specify posts' format(e.g. format="html", format="text", format="raw").
 func (trc *TumblrRestClient) Drafts(blogname string, options map[string]string) DraftsResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/posts/draft", blogname)
+	requestUrl := blogPath(blogname, "/posts/draft")
 	data := trc.request.Get(requestUrl, options)
 	var result DraftsResponse
 	json.Unmarshal(data.Response, &result)
@@ -200,7 +269,7 @@ func (trc *TumblrRestClient) Drafts(blogname string, options map[string]string)
 //offset: post number to start at;
 //filter: specify posts' format(e.g. format="html", format="text", format="raw").
 func (trc *TumblrRestClient) Submission(blogname string, options map[string]string) DraftsResponse {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/posts/submission", blogname)
+	requestUrl := blogPath(blogname, "/posts/submission")
 	data := trc.request.Get(requestUrl, options)
 	var result DraftsResponse
 	json.Unmarshal(data.Response, &result)
@@ -271,14 +340,14 @@ func (trc *TumblrRestClient) Unlike(id, reblogKey string) error {
 //caption: the caption that you want applied to the photo;
 //link: the 'click-through' url for the photo;
 //*source: the photo source url.
-func (trc *TumblrRestClient) CreatePhoto(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreatePhoto(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("photo", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "photo"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create a text post on a blog.
@@ -294,14 +363,14 @@ func (trc *TumblrRestClient) CreatePhoto(blogname string, options map[string]str
 //slug: add a short text summary to the end of the post url;
 //title: the optional title of the post;
 //*body: the full text body.
-func (trc *TumblrRestClient) CreateText(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateText(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("text", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "text"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create a quote post on a blog.
@@ -317,14 +386,14 @@ func (trc *TumblrRestClient) CreateText(blogname string, options map[string]stri
 //slug: add a short text summary to the end of the post url;
 //*quote: the full text of the quote;
 //source: the cited source of the quote.
-func (trc *TumblrRestClient) CreateQuote(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateQuote(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("quote", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "quote"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create a link post on a blog.
@@ -341,14 +410,14 @@ func (trc *TumblrRestClient) CreateQuote(blogname string, options map[string]str
 //title: the title of the page the link points to;
 //*url: the link you are posting;
 //description: the description of the link you are posting.
-func (trc *TumblrRestClient) CreateLink(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateLink(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("link", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "link"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create a chat post on a blog.
@@ -364,14 +433,14 @@ func (trc *TumblrRestClient) CreateLink(blogname string, options map[string]stri
 //slug: add a short text summary to the end of the post url;
 //title: the title of the chat;
 //*conversation: the text of the conversation/chat, with dialogue labels.
-func (trc *TumblrRestClient) CreateChatPost(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateChatPost(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("chat", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "chat"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create an audio post on a blog.
@@ -387,14 +456,14 @@ func (trc *TumblrRestClient) CreateChatPost(blogname string, options map[string]
 //slug: add a short text summary to the end of the post url;
 //caption: the caption of the post;
 //*external_url: the url of the site that hosts the audio file.
-func (trc *TumblrRestClient) CreateAudio(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateAudio(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("audio", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "audio"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Create a video post on a blog.
@@ -410,14 +479,14 @@ func (trc *TumblrRestClient) CreateAudio(blogname string, options map[string]str
 //slug: add a short text summary to the end of the post url;
 //caption: the caption for the post;
 //*embed: the html embed code for the video.
-func (trc *TumblrRestClient) CreateVideo(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post", blogname)
+func (trc *TumblrRestClient) CreateVideo(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost("video", options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
 	options["type"] = "video"
 	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
-	}
-	return nil
+	return postResult(data, 201)
 }
 
 //Creates a reblog on the given blog.
@@ -426,20 +495,20 @@ func (trc *TumblrRestClient) CreateVideo(blogname string, options map[string]str
 //(with * are marked required options)
 //*id: the id of the reblogged post;
 //*reblog_key: the reblog key of the rebloged post.
-func (trc *TumblrRestClient) Reblog(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post/reblog", blogname)
-	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 201 {
-		return errors.New(data.Meta.Msg)
+func (trc *TumblrRestClient) Reblog(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidateReblog(options); err != nil {
+		return PostResult{}, err
 	}
-	return nil
+	requestUrl := blogPath(blogname, "/post/reblog")
+	data := trc.request.Post(requestUrl, options)
+	return postResult(data, 201)
 }
 
 //Deletes a post with a given id.
 //blogname: the url of the blog you want to delete from.
 //id: the id of the post you want to delete.
 func (trc *TumblrRestClient) DeletePost(blogname, id string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post/delete", blogname)
+	requestUrl := blogPath(blogname, "/post/delete")
 	params := map[string]string{"id": id}
 	data := trc.request.Post(requestUrl, params)
 	if data.Meta.Status != 200 {
@@ -460,11 +529,11 @@ func (trc *TumblrRestClient) DeletePost(blogname, id string) error {
 //slug: add a short text summary to the end of the post url;
 //*id: the id of the post.
 //The other options are specific to the type of post you want to edit.
-func (trc *TumblrRestClient) EditPost(blogname string, options map[string]string) error {
-	requestUrl := fmt.Sprintf("/v2/blog/%s/post/edit", blogname)
-	data := trc.request.Post(requestUrl, options)
-	if data.Meta.Status != 200 {
-		return errors.New(data.Meta.Msg)
+func (trc *TumblrRestClient) EditPost(blogname string, options map[string]string) (PostResult, error) {
+	if err := ValidateEdit(options); err != nil {
+		return PostResult{}, err
 	}
-	return nil
+	requestUrl := blogPath(blogname, "/post/edit")
+	data := trc.request.Post(requestUrl, options)
+	return postResult(data, 200)
 }
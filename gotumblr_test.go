@@ -318,9 +318,9 @@ func TestCreatePhoto(t *testing.T) {
 
 	response := `{"meta": {"status":400, "msg": "Bad Request"}}`
 
-	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"state": "draft"}, t)
+	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"state": "draft", "source": "http://pics.com/cat.jpg"}, t)
 
-	post_photo := client.CreatePhoto("mgterzieva", map[string]string{"state": "draft"})
+	_, post_photo := client.CreatePhoto("mgterzieva", map[string]string{"state": "draft", "source": "http://pics.com/cat.jpg"})
 	want := errors.New("Bad Request")
 	if !reflect.DeepEqual(post_photo, want) {
 		t.Errorf("CreatePhoto returned %+v, want %+v", post_photo, want)
@@ -331,14 +331,17 @@ func TestCreateText(t *testing.T) {
 	setup()
 	defer teardown()
 
-	response := `{"meta": {"status": 201, "msg": "Created"}}`
+	response := `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": 72078164824}}`
 
 	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"body": "Hello, hello!"}, t)
 
-	post_text := client.CreateText("mgterzieva", map[string]string{"body": "Hello, hello!"})
+	result, post_text := client.CreateText("mgterzieva", map[string]string{"body": "Hello, hello!"})
 	if post_text != nil {
 		t.Errorf("CreateText returned %+v, want %+v", post_text, nil)
 	}
+	if want := (PostResult{ID: "72078164824"}); result != want {
+		t.Errorf("CreateText returned %+v, want %+v", result, want)
+	}
 }
 
 func TestCreateQuote(t *testing.T) {
@@ -351,7 +354,7 @@ func TestCreateQuote(t *testing.T) {
 
 	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"source": source, "quote": quote}, t)
 
-	post_quote := client.CreateQuote("mgterzieva", map[string]string{"source": source, "quote": quote})
+	_, post_quote := client.CreateQuote("mgterzieva", map[string]string{"source": source, "quote": quote})
 	if post_quote != nil {
 		t.Errorf("CreateQuote returned %+v, want %+v", post_quote, nil)
 	}
@@ -363,9 +366,9 @@ func TestCreateChatPost(t *testing.T) {
 
 	response := `{"meta": {"status": 400, "msg": "Bad Request"}}`
 
-	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{}, t)
+	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"conversation": "Alice: Hi!"}, t)
 
-	post_discussion := client.CreateChatPost("mgterzieva", map[string]string{})
+	_, post_discussion := client.CreateChatPost("mgterzieva", map[string]string{"conversation": "Alice: Hi!"})
 	want := errors.New("Bad Request")
 	if !reflect.DeepEqual(post_discussion, want) {
 		t.Errorf("CreateChatPost returned %+v, want %+v", post_discussion, nil)
@@ -380,7 +383,7 @@ func TestCreateAudio(t *testing.T) {
 
 	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"external_url": "http://coolsongs.com/song"}, t)
 
-	post_song := client.CreateAudio("mgterzieva", map[string]string{"external_url": "http://coolsongs.com/song"})
+	_, post_song := client.CreateAudio("mgterzieva", map[string]string{"external_url": "http://coolsongs.com/song"})
 	if post_song != nil {
 		t.Errorf("CreateAudio returned %+v, want %+v", post_song, nil)
 	}
@@ -394,7 +397,7 @@ func TestCreateVideo(t *testing.T) {
 
 	handleFunc("/v2/blog/mgterzieva/post", "POST", response, map[string]string{"embed": code}, t)
 
-	post_video := client.CreateVideo("mgterzieva", map[string]string{"embed": code})
+	_, post_video := client.CreateVideo("mgterzieva", map[string]string{"embed": code})
 	if post_video != nil {
 		t.Errorf("CreateVideo returned %+v, want %+v", post_video, nil)
 	}
@@ -408,7 +411,7 @@ func TestReblog(t *testing.T) {
 
 	handleFunc("/v2/blog/mgterzieva/post/reblog", "POST", response, map[string]string{"id": "7161981", "reblog_key": "blah"}, t)
 
-	reblog := client.Reblog("mgterzieva", map[string]string{"id": "7161981", "reblog_key": "blah"})
+	_, reblog := client.Reblog("mgterzieva", map[string]string{"id": "7161981", "reblog_key": "blah"})
 	want := errors.New("Bad Request")
 	if !reflect.DeepEqual(reblog, want) {
 		t.Errorf("Reblog returned %+v, want %+v", reblog, nil)
@@ -436,9 +439,9 @@ func TestEditPost(t *testing.T) {
 
 	response := `{"meta": {"status": 400, "msg": "Bad Request"}}`
 
-	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", response, map[string]string{}, t)
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", response, map[string]string{"id": "7161981"}, t)
 
-	edit := client.EditPost("mgterzieva", map[string]string{})
+	_, edit := client.EditPost("mgterzieva", map[string]string{"id": "7161981"})
 	want := errors.New("Bad Request")
 	if !reflect.DeepEqual(edit, want) {
 		t.Errorf("EditPost returned %+v, want %+v", edit, want)
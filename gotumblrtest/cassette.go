@@ -0,0 +1,300 @@
+package gotumblrtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//A recorded HTTP request.
+//Params holds the query and form parameters of the request, sorted and url-encoded,
+//without the OAuth parameters and the api_key.
+//Body holds the body of requests that are not form-encoded, such as JSON ones.
+//The fields of multipart bodies are recorded in Params, files as <file name>:sha256:<checksum>,
+//since their boundary changes with every request.
+type CassetteRequest struct {
+	Method string
+	Path   string
+	Params string
+	Body   string `json:",omitempty"`
+}
+
+//A recorded HTTP response.
+//Body holds the body as text, or base64-encoded if Encoding is "base64",
+//which is how bodies that aren't valid UTF-8, such as images, are recorded.
+type CassetteResponse struct {
+	Status   int
+	Header   http.Header
+	Body     string
+	Encoding string `json:",omitempty"`
+}
+
+//A request made to the Tumblr API and the response it got.
+type Interaction struct {
+	Request  CassetteRequest
+	Response CassetteResponse
+}
+
+//A list of recorded interactions, stored as JSON in a cassette file.
+type Cassette struct {
+	Interactions []Interaction
+}
+
+//Loads the cassette stored in the file with the given path.
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cassette := &Cassette{}
+	if err := json.Unmarshal(content, cassette); err != nil {
+		return nil, fmt.Errorf("gotumblrtest: cassette %s: %v", path, err)
+	}
+	return cassette, nil
+}
+
+//Stores the cassette in the file with the given path.
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
+
+//Recorder is an http.RoundTripper that sends requests through another
+//http.RoundTripper and records them along with their responses.
+//OAuth headers and parameters, the api_key and cookies are never recorded.
+//Use it with TumblrRestClient.SetTransport and call Save when you are done.
+type Recorder struct {
+	transport http.RoundTripper
+	path      string
+	mu        sync.Mutex
+	cassette  Cassette
+}
+
+//Creates a Recorder that writes its cassette to the file with the given path.
+//transport: the http.RoundTripper that sends the requests; nil means http.DefaultTransport.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport, path: path}
+}
+
+//Sends the request and records it along with its response.
+func (r *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	header := http.Header{}
+	for key, values := range response.Header {
+		if key != "Set-Cookie" {
+			header[key] = values
+		}
+	}
+	recordedResponse := CassetteResponse{Status: response.StatusCode, Header: header, Body: string(body)}
+	if !utf8.Valid(body) {
+		recordedResponse.Body = base64.StdEncoding.EncodeToString(body)
+		recordedResponse.Encoding = "base64"
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{recorded, recordedResponse})
+	return response, nil
+}
+
+//Writes the recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+//Replayer is an http.RoundTripper that answers requests with the responses
+//recorded in a cassette, without using the network.
+//A request matches an interaction with the same method, path and parameters;
+//the OAuth nonce, timestamp and signature are ignored.
+//Requests that match no interaction fail with an error.
+type Replayer struct {
+	mu        sync.Mutex
+	cassette  *Cassette
+	used      []bool
+	unmatched []string
+}
+
+//Creates a Replayer for the cassette stored in the file with the given path.
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}, nil
+}
+
+//Answers the request with the first unused matching interaction in the cassette.
+//When all matching interactions are used, the last one is answered again.
+func (r *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request == recorded {
+			match = i
+			if !r.used[i] {
+				break
+			}
+		}
+	}
+	if match == -1 {
+		description := recorded.Method + " " + recorded.Path
+		if recorded.Params != "" {
+			description += "?" + recorded.Params
+		}
+		r.unmatched = append(r.unmatched, description)
+		return nil, fmt.Errorf("gotumblrtest: no recorded interaction matches %s", description)
+	}
+	response := r.cassette.Interactions[match].Response
+	body := []byte(response.Body)
+	switch response.Encoding {
+	case "":
+	case "base64":
+		if body, err = base64.StdEncoding.DecodeString(response.Body); err != nil {
+			return nil, fmt.Errorf("gotumblrtest: the response to %s %s: %v", recorded.Method, recorded.Path, err)
+		}
+	default:
+		return nil, fmt.Errorf("gotumblrtest: the response to %s %s has the unknown encoding %q", recorded.Method, recorded.Path, response.Encoding)
+	}
+	r.used[match] = true
+	header := http.Header{}
+	for key, values := range response.Header {
+		header[key] = values
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+		StatusCode:    response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+//Returns an error describing the requests that matched no interaction
+//and the interactions that were never replayed, or nil if there are none.
+func (r *Replayer) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	problems := []string{}
+	for _, description := range r.unmatched {
+		problems = append(problems, "unmatched request "+description)
+	}
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			problems = append(problems, "unused interaction "+interaction.Request.Method+" "+interaction.Request.Path)
+		}
+	}
+	if len(problems) != 0 {
+		return errors.New("gotumblrtest: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+//Describes the request the way it is stored in a cassette.
+//The request body is read and replaced so that it can still be sent.
+func recordRequest(request *http.Request) (CassetteRequest, error) {
+	params := url.Values{}
+	for key, values := range request.URL.Query() {
+		params[key] = append(params[key], values...)
+	}
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, err
+		}
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if strings.HasPrefix(request.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return CassetteRequest{}, err
+		}
+		for key, values := range form {
+			params[key] = append(params[key], values...)
+		}
+		body = nil
+	}
+	if mediaType, typeParams, err := mime.ParseMediaType(request.Header.Get("Content-Type")); err == nil && mediaType == "multipart/form-data" {
+		if err := recordMultipart(params, body, typeParams["boundary"]); err != nil {
+			return CassetteRequest{}, err
+		}
+		body = nil
+	}
+	for key := range params {
+		if key == "api_key" || strings.HasPrefix(key, "oauth_") {
+			delete(params, key)
+		} else {
+			sort.Strings(params[key])
+		}
+	}
+	return CassetteRequest{request.Method, request.URL.Path, params.Encode(), string(body)}, nil
+}
+
+//Adds the fields and the files of a multipart body to params.
+func recordMultipart(params url.Values, body []byte, boundary string) error {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return err
+		}
+		value := string(content)
+		if part.FileName() != "" {
+			sum := sha256.Sum256(content)
+			value = part.FileName() + ":sha256:" + hex.EncodeToString(sum[:])
+		}
+		params.Add(part.FormName(), value)
+	}
+}
@@ -0,0 +1,154 @@
+package gotumblrtest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	s := newServer()
+	recorder := NewRecorder(path, nil)
+	client := s.Client()
+	client.SetTransport(recorder)
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello, hello!"})
+	recorded := client.Posts("mgterzieva", "text", map[string]string{})
+	s.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned %+v, want %+v", err, nil)
+	}
+
+	content, _ := ioutil.ReadFile(path)
+	for _, secret := range []string{"consumer_key", "token", "oauth_signature"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette contains %v", secret)
+		}
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer returned %+v, want %+v", err, nil)
+	}
+	client = s.Client()
+	client.SetTransport(replayer)
+	if _, err := client.CreateText("mgterzieva", map[string]string{"body": "Hello, hello!"}); err != nil {
+		t.Errorf("CreateText returned %+v, want %+v", err, nil)
+	}
+	replayed := client.Posts("mgterzieva", "text", map[string]string{})
+	if replayed.Total_posts != recorded.Total_posts || replayed.Total_posts != 1 {
+		t.Errorf("Total_posts returned %v, want %v", replayed.Total_posts, recorded.Total_posts)
+	}
+	if err := replayer.Verify(); err != nil {
+		t.Errorf("Verify returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &Cassette{[]Interaction{{
+		CassetteRequest{Method: "GET", Path: "/v2/blog/mgterzieva/info"},
+		CassetteResponse{Status: 200, Header: http.Header{}, Body: `{"response": {"blog": {"name": "mgterzieva"}}}`},
+	}}}
+	cassette.Save(path)
+	replayer, _ := NewReplayer(path)
+
+	request, _ := http.NewRequest("GET", "http://api.tumblr.com/v2/blog/mgterzieva/info?api_key=key&oauth_nonce=1", nil)
+	if _, err := replayer.RoundTrip(request); err != nil {
+		t.Errorf("RoundTrip returned %+v, want %+v", err, nil)
+	}
+	request, _ = http.NewRequest("GET", "http://api.tumblr.com/v2/blog/mgterzieva/followers", nil)
+	if _, err := replayer.RoundTrip(request); err == nil {
+		t.Errorf("RoundTrip of an unrecorded request returned %+v, want an error", err)
+	}
+	if err := replayer.Verify(); err == nil || !strings.Contains(err.Error(), "GET /v2/blog/mgterzieva/followers") {
+		t.Errorf("Verify returned %+v, want the unmatched request", err)
+	}
+}
+
+func TestRecordJSONBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &Cassette{[]Interaction{{
+		CassetteRequest{Method: "POST", Path: "/v2/blog/mgterzieva/posts", Body: `{"content":[{"type":"text","text":"Hello"}]}`},
+		CassetteResponse{Status: 201, Header: http.Header{}, Body: `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": "1001"}}`},
+	}}}
+	cassette.Save(path)
+	replayer, _ := NewReplayer(path)
+
+	request, _ := http.NewRequest("POST", "http://api.tumblr.com/v2/blog/mgterzieva/posts", strings.NewReader(`{"content":[{"type":"text","text":"Bye"}]}`))
+	request.Header.Set("Content-Type", "application/json")
+	if _, err := replayer.RoundTrip(request); err == nil {
+		t.Errorf("RoundTrip with another body returned %+v, want an error", err)
+	}
+	request, _ = http.NewRequest("POST", "http://api.tumblr.com/v2/blog/mgterzieva/posts", strings.NewReader(`{"content":[{"type":"text","text":"Hello"}]}`))
+	request.Header.Set("Content-Type", "application/json")
+	if _, err := replayer.RoundTrip(request); err != nil {
+		t.Errorf("RoundTrip returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestRecordAndReplayBinaryBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	image := []byte("\x89PNG\r\n\x1a\n\x00\xff\xfe")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(image)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(path, nil)
+	response, err := (&http.Client{Transport: recorder}).Get(server.URL + "/avatar.png")
+	if err != nil {
+		t.Fatalf("Get returned %+v, want %+v", err, nil)
+	}
+	recorded, _ := ioutil.ReadAll(response.Body)
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save returned %+v, want %+v", err, nil)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer returned %+v, want %+v", err, nil)
+	}
+	response, err = (&http.Client{Transport: replayer}).Get(server.URL + "/avatar.png")
+	if err != nil {
+		t.Fatalf("Get returned %+v, want %+v", err, nil)
+	}
+	replayed, _ := ioutil.ReadAll(response.Body)
+	if !bytes.Equal(recorded, image) || !bytes.Equal(replayed, image) {
+		t.Errorf("recorded %q and replayed %q, want %q", recorded, replayed, image)
+	}
+}
+
+func TestRecordMultipartBody(t *testing.T) {
+	upload := func() *http.Request {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("type", "photo")
+		part, _ := writer.CreateFormFile("data[0]", "cat.jpg")
+		part.Write([]byte("a cat"))
+		writer.Close()
+		request, _ := http.NewRequest("POST", "http://api.tumblr.com/v2/blog/mgterzieva/post", &body)
+		request.Header.Set("Content-Type", writer.FormDataContentType())
+		return request
+	}
+	first, err := recordRequest(upload())
+	if err != nil {
+		t.Fatalf("recordRequest returned %+v, want %+v", err, nil)
+	}
+	second, _ := recordRequest(upload())
+	want := CassetteRequest{
+		Method: "POST",
+		Path:   "/v2/blog/mgterzieva/post",
+		Params: "data%5B0%5D=cat.jpg%3Asha256%3A51e467415607798220a3776f6ae1a2a09ddc7e5dcdc955d685477b4cf05ade22&type=photo",
+	}
+	if first != want || second != want {
+		t.Errorf("recordRequest returned %+v and %+v, want %+v", first, second, want)
+	}
+}
@@ -0,0 +1,1162 @@
+//An in-memory fake of the Tumblr API v2 for testing code that uses gotumblr.
+
+package gotumblrtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MariaTerzieva/gotumblr"
+)
+
+//Server is a stateful fake Tumblr API server.
+//Posts created through it show up in Posts, Queue and Drafts,
+//likes show up in Likes and follows show up in Following and Followers.
+//Requests are rejected unless they are signed with the server's credentials.
+type Server struct {
+	*httptest.Server
+
+	ConsumerKey    string
+	ConsumerSecret string
+	Token          string
+	TokenSecret    string
+
+	//Whether to reject requests without a valid OAuth signature. It is true for new servers.
+	VerifySignatures bool
+
+	mu              sync.Mutex
+	user            string
+	blogs           map[string]*fakeBlog
+	order           []string
+	posts           map[int64]*fakePost
+	nextId          int64
+	likes           []int64
+	following       []string
+	filteredTags    []string
+	filteredContent []string
+	failures        map[string][]failure
+	limit           int
+	requests        int
+	media           map[string][]byte
+	mediaRequests   map[string]int
+}
+
+type fakeBlog struct {
+	info      gotumblr.BlogInfo
+	owned     bool
+	followers []gotumblr.User
+	blocks    []string
+	//The ids of the blog's queued posts, in the order they will be published.
+	queue         []int64
+	notifications []gotumblr.Notification
+}
+
+type fakePost struct {
+	blog   string
+	fields map[string]interface{}
+	muted  bool
+}
+
+type failure struct {
+	status int
+	msg    string
+}
+
+//Starts a new fake server for the user with the given name.
+//consumerKey, consumerSecret, token and tokenSecret are the credentials
+//that clients have to sign their requests with.
+func NewServer(user, consumerKey, consumerSecret, token, tokenSecret string) *Server {
+	s := &Server{
+		ConsumerKey:      consumerKey,
+		ConsumerSecret:   consumerSecret,
+		Token:            token,
+		TokenSecret:      tokenSecret,
+		VerifySignatures: true,
+		user:             user,
+		blogs:            map[string]*fakeBlog{},
+		posts:            map[int64]*fakePost{},
+		nextId:           1000,
+		filteredTags:     []string{},
+		filteredContent:  []string{},
+		failures:         map[string][]failure{},
+		media:            map[string][]byte{},
+		mediaRequests:    map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+//Returns a TumblrRestClient configured with the server's url and credentials.
+func (s *Server) Client() *gotumblr.TumblrRestClient {
+	return gotumblr.NewTumblrRestClient(s.ConsumerKey, s.ConsumerSecret, s.Token, s.TokenSecret, "", s.URL)
+}
+
+//Adds a blog to the server.
+//owned: whether the blog belongs to the server's user.
+func (s *Server) AddBlog(name string, owned bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name = gotumblr.NormalizeBlogIdentifier(name)
+	if _, ok := s.blogs[name]; ok {
+		return
+	}
+	s.blogs[name] = &fakeBlog{
+		info:  gotumblr.BlogInfo{Name: name, Uuid: "t:" + name, Title: name, Url: "http://" + name + ".tumblr.com/"},
+		owned: owned,
+	}
+	s.order = append(s.order, name)
+}
+
+//Adds a notification to a blog on the server.
+//Likes of the blog's posts and follows of the blog add notifications too.
+//The notification gets the current time if it has no Timestamp.
+func (s *Server) AddNotification(blogname string, notification gotumblr.Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := gotumblr.NormalizeBlogIdentifier(blogname)
+	if blog, ok := s.blogs[name]; ok {
+		s.notify(blog, notification)
+	}
+}
+
+func (s *Server) notify(blog *fakeBlog, notification gotumblr.Notification) {
+	if notification.Timestamp == 0 {
+		notification.Timestamp = time.Now().Unix()
+	}
+	notification.Target_tumblelog_name = blog.info.Name
+	notification.Unread = true
+	blog.notifications = append(blog.notifications, notification)
+}
+
+//Makes the next request with the given method and path fail.
+//method: the HTTP method of the request (e.g. GET).
+//path: the path of the request (e.g. /v2/blog/mgterzieva/post).
+//status and msg: the meta status and message returned.
+//Calling FailNext several times for the same request queues the failures.
+func (s *Server) FailNext(method, path string, status int, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := method + " " + path
+	s.failures[key] = append(s.failures[key], failure{status, msg})
+}
+
+//Adds a media file, such as a photo, to the server and returns its url.
+//Media is served without checking signatures and supports Range requests.
+func (s *Server) AddMedia(name string, content []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.media[name] = content
+	return s.URL + "/media/" + name
+}
+
+//Returns the number of times the media file with the given name was requested.
+func (s *Server) MediaRequests(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mediaRequests[name]
+}
+
+//Limits the number of requests the server answers before responding with 429 Limit Exceeded.
+//A limit of 0 means no limit. Setting the limit resets the count of requests made.
+func (s *Server) SetRateLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+	s.requests = 0
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limit != 0 {
+		remaining := s.limit - s.requests
+		if remaining < 0 {
+			remaining = 0
+		}
+		w.Header().Set("X-Ratelimit-Perhour-Limit", strconv.Itoa(s.limit))
+		w.Header().Set("X-Ratelimit-Perhour-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-Ratelimit-Perhour-Reset", "3600")
+		if remaining == 0 {
+			writeMeta(w, 429, "Limit Exceeded")
+			return
+		}
+		s.requests++
+	}
+	key := r.Method + " " + r.URL.Path
+	if failures := s.failures[key]; len(failures) != 0 {
+		s.failures[key] = failures[1:]
+		writeMeta(w, failures[0].status, failures[0].msg)
+		return
+	}
+	if s.VerifySignatures && !isMedia(r.URL.Path) {
+		if err := s.verify(r); err != nil {
+			writeMeta(w, 401, "Unauthorized")
+			return
+		}
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			writeMeta(w, 400, "Bad Request")
+			return
+		}
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/media/avatar_") {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(avatarImage)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/media/") {
+		name := strings.TrimPrefix(r.URL.Path, "/media/")
+		content, ok := s.media[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		s.mediaRequests[name]++
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+		return
+	}
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(path) < 2 || path[0] != "v2" {
+		writeMeta(w, 404, "Not Found")
+		return
+	}
+	switch {
+	case path[1] == "user" && len(path) == 3:
+		s.serveUser(w, r, path[2])
+	case path[1] == "user" && len(path) == 4 && path[2] == "filtered_tags" && r.Method == "DELETE":
+		if !contains(s.filteredTags, path[3]) {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		s.filteredTags = remove(s.filteredTags, path[3])
+		writeResponse(w, 200, map[string]interface{}{})
+	case path[1] == "blog" && len(path) >= 4:
+		s.serveBlog(w, r, gotumblr.NormalizeBlogIdentifier(path[2]), path[3:])
+	case path[1] == "tagged" && len(path) == 2 && r.Method == "GET":
+		s.serveTagged(w, r)
+	default:
+		writeMeta(w, 404, "Not Found")
+	}
+}
+
+func (s *Server) serveUser(w http.ResponseWriter, r *http.Request, endpoint string) {
+	switch r.Method + " " + endpoint {
+	case "GET info":
+		blogs := []gotumblr.OwnedBlog{}
+		for _, name := range s.order {
+			if blog := s.blogs[name]; blog.owned {
+				blogs = append(blogs, gotumblr.OwnedBlog{
+					Name:      name,
+					Url:       blog.info.Url,
+					Title:     blog.info.Title,
+					Primary:   len(blogs) == 0,
+					Followers: int64(len(blog.followers)),
+					Type:      "public",
+				})
+			}
+		}
+		writeResponse(w, 200, map[string]interface{}{"user": gotumblr.UserInfo{
+			Name:                s.user,
+			Likes:               int64(len(s.likes)),
+			Following:           int64(len(s.following)),
+			Default_post_format: "html",
+			Blogs:               blogs,
+		}})
+	case "GET limits":
+		reset := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour).Unix()
+		limit := func(description string, limit, used int) gotumblr.Limit {
+			return gotumblr.Limit{Description: description, Limit: int64(limit), Remaining: int64(limit - used), Reset_at: reset}
+		}
+		writeResponse(w, 200, map[string]interface{}{"user": gotumblr.UserLimits{
+			Follows: limit("Number of blogs you can follow per day", 200, len(s.following)),
+			Likes:   limit("Number of posts you can like per day", 1000, len(s.likes)),
+			Photos:  limit("Number of photos you can upload per day", 150, s.countType("photo")),
+			Posts:   limit("Number of posts you can create per day", 250, len(s.posts)),
+			Videos:  limit("Number of videos you can upload per day", 20, s.countType("video")),
+		}})
+	case "GET filtered_tags":
+		writeResponse(w, 200, map[string]interface{}{"filtered_tags": s.filteredTags})
+	case "POST filtered_tags":
+		//A single filtered_tags value is a comma separated list; indexed values are a tag each.
+		tags := []string{}
+		for _, value := range r.Form["filtered_tags"] {
+			tags = append(tags, strings.Split(value, ",")...)
+		}
+		tags = append(tags, formValues(r, "filtered_tags")[len(r.Form["filtered_tags"]):]...)
+		for _, tag := range tags {
+			if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" && !contains(s.filteredTags, tag) {
+				s.filteredTags = append(s.filteredTags, tag)
+			}
+		}
+		writeResponse(w, 201, map[string]interface{}{})
+	case "GET filtered_content":
+		writeResponse(w, 200, map[string]interface{}{"filtered_content": s.filteredContent})
+	case "POST filtered_content":
+		for _, content := range formValues(r, "filtered_content") {
+			if content != "" && !contains(s.filteredContent, content) {
+				s.filteredContent = append(s.filteredContent, content)
+			}
+		}
+		writeResponse(w, 201, map[string]interface{}{})
+	case "DELETE filtered_content":
+		content := r.Form.Get("filtered_content")
+		if !contains(s.filteredContent, content) {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		s.filteredContent = remove(s.filteredContent, content)
+		writeResponse(w, 200, map[string]interface{}{})
+	case "GET likes":
+		s.serveLikes(w, r)
+	case "GET following":
+		blogs := []gotumblr.FollowedBlog{}
+		for _, name := range s.following {
+			blogs = append(blogs, s.followedBlog(name))
+		}
+		total := len(blogs)
+		blogs = paginateBlogs(blogs, r)
+		writeResponse(w, 200, map[string]interface{}{"total_blogs": total, "blogs": blogs})
+	case "GET dashboard":
+		posts, _ := s.filterPosts(r, func(post *fakePost) bool {
+			return post.fields["state"] == "published" && (s.blogs[post.blog].owned || contains(s.following, post.blog))
+		})
+		writeResponse(w, 200, map[string]interface{}{"posts": posts})
+	case "POST follow", "POST unfollow":
+		name := gotumblr.NormalizeBlogIdentifier(r.Form.Get("url"))
+		blog, ok := s.blogs[name]
+		if !ok {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		if endpoint == "follow" && !contains(s.following, name) {
+			s.following = append(s.following, name)
+			blog.followers = append(blog.followers, gotumblr.User{Name: s.user, Url: "http://" + s.user + ".tumblr.com/"})
+			s.notify(blog, gotumblr.Notification{Type: gotumblr.NotificationFollow, From_tumblelog_name: s.user})
+		} else if endpoint == "unfollow" {
+			s.following = remove(s.following, name)
+			for i, follower := range blog.followers {
+				if follower.Name == s.user {
+					blog.followers = append(blog.followers[:i], blog.followers[i+1:]...)
+					break
+				}
+			}
+		}
+		writeResponse(w, 200, map[string]interface{}{})
+	case "POST like", "POST unlike":
+		post := s.postByKey(r.Form.Get("id"), r.Form.Get("reblog_key"))
+		if post == nil {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		id := post.fields["id"].(int64)
+		s.likes = removeId(s.likes, id)
+		if endpoint == "like" {
+			s.likes = append([]int64{id}, s.likes...)
+		}
+		if endpoint == "like" && !post.muted {
+			s.notify(s.blogs[post.blog], gotumblr.Notification{
+				Type:                gotumblr.NotificationLike,
+				From_tumblelog_name: s.user,
+				Target_post_id:      strconv.FormatInt(id, 10),
+				Target_post_type:    post.fields["type"].(string),
+			})
+		}
+		writeResponse(w, 200, map[string]interface{}{})
+	default:
+		writeMeta(w, 404, "Not Found")
+	}
+}
+
+//Serves the liked posts, the most recently liked first.
+func (s *Server) serveLikes(w http.ResponseWriter, r *http.Request) {
+	posts := []map[string]interface{}{}
+	for i := len(s.likes) - 1; i >= 0; i-- {
+		if post, ok := s.posts[s.likes[i]]; ok {
+			posts = append(posts, s.render(post))
+		}
+	}
+	total := len(posts)
+	offset, limit := pagination(r)
+	if offset > len(posts) {
+		offset = len(posts)
+	}
+	posts = posts[offset:]
+	if limit < len(posts) {
+		posts = posts[:limit]
+	}
+	writeResponse(w, 200, map[string]interface{}{"liked_posts": posts, "liked_count": total})
+}
+
+func (s *Server) serveTagged(w http.ResponseWriter, r *http.Request) {
+	tag := r.Form.Get("tag")
+	posts, _ := s.filterPosts(r, func(post *fakePost) bool {
+		return post.fields["state"] == "published" && contains(post.fields["tags"].([]string), tag)
+	})
+	writeResponse(w, 200, posts)
+}
+
+func (s *Server) serveBlog(w http.ResponseWriter, r *http.Request, name string, endpoint []string) {
+	blog, ok := s.blogs[name]
+	if !ok {
+		writeMeta(w, 404, "Not Found")
+		return
+	}
+	ownedOnly := func() bool {
+		if !blog.owned {
+			writeMeta(w, 403, "Forbidden")
+		}
+		return blog.owned
+	}
+	switch r.Method + " " + strings.Join(endpoint, "/") {
+	case "GET info":
+		blog.info.Posts = int64(s.count(name, "published"))
+		writeResponse(w, 200, map[string]interface{}{"blog": blog.info})
+	case "GET followers":
+		if !ownedOnly() {
+			return
+		}
+		users := blog.followers
+		total := len(users)
+		offset, limit := pagination(r)
+		if offset > len(users) {
+			offset = len(users)
+		}
+		users = users[offset:]
+		if limit < len(users) {
+			users = users[:limit]
+		}
+		writeResponse(w, 200, map[string]interface{}{"total_users": total, "users": users})
+	case "GET following":
+		if !ownedOnly() {
+			return
+		}
+		blogs := []gotumblr.FollowedBlog{}
+		for _, name := range s.following {
+			blogs = append(blogs, s.followedBlog(name))
+		}
+		total := len(blogs)
+		writeResponse(w, 200, map[string]interface{}{"total_blogs": total, "blogs": paginateBlogs(blogs, r)})
+	case "GET followed_by":
+		if !ownedOnly() {
+			return
+		}
+		query := gotumblr.NormalizeBlogIdentifier(r.Form.Get("query"))
+		followed := query == s.user && contains(s.following, name)
+		for _, follower := range blog.followers {
+			followed = followed || follower.Name == query
+		}
+		writeResponse(w, 200, map[string]interface{}{"followed_by": followed})
+	case "GET notifications":
+		if !ownedOnly() {
+			return
+		}
+		before, _ := strconv.ParseInt(r.Form.Get("before"), 10, 64)
+		types := formValues(r, "types")
+		notifications := []gotumblr.Notification{}
+		for i := len(blog.notifications) - 1; i >= 0; i-- {
+			notification := blog.notifications[i]
+			if (before == 0 || notification.Timestamp < before) && (len(types) == 0 || contains(types, string(notification.Type))) {
+				notifications = append(notifications, notification)
+			}
+		}
+		sort.SliceStable(notifications, func(i, j int) bool {
+			return notifications[i].Timestamp > notifications[j].Timestamp
+		})
+		response := map[string]interface{}{}
+		if len(notifications) > notificationsPageSize {
+			notifications = notifications[:notificationsPageSize]
+			next := strconv.FormatInt(notifications[len(notifications)-1].Timestamp, 10)
+			response["_links"] = map[string]interface{}{"next": map[string]interface{}{
+				"href":         "/v2/blog/" + name + "/notifications?before=" + next,
+				"method":       "GET",
+				"query_params": map[string]string{"before": next},
+			}}
+		}
+		response["notifications"] = notifications
+		writeResponse(w, 200, response)
+	case "GET blocks":
+		if !ownedOnly() {
+			return
+		}
+		blogs := []gotumblr.FollowedBlog{}
+		for _, name := range blog.blocks {
+			blogs = append(blogs, s.followedBlog(name))
+		}
+		writeResponse(w, 200, map[string]interface{}{"blocked_tumblelogs": paginateBlogs(blogs, r)})
+	case "POST blocks", "POST blocks/bulk":
+		if !ownedOnly() {
+			return
+		}
+		targets := []string{r.Form.Get("blocked_tumblelog")}
+		if len(endpoint) == 2 {
+			targets = strings.Split(r.Form.Get("blocked_tumblelogs"), ",")
+		}
+		for _, target := range targets {
+			if _, ok := s.blogs[target]; !ok {
+				writeMeta(w, 404, "Not Found")
+				return
+			}
+		}
+		for _, target := range targets {
+			if !contains(blog.blocks, target) {
+				blog.blocks = append(blog.blocks, target)
+			}
+		}
+		writeResponse(w, 201, map[string]interface{}{})
+	case "DELETE blocks":
+		if !ownedOnly() {
+			return
+		}
+		target := r.Form.Get("blocked_tumblelog")
+		if !contains(blog.blocks, target) {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		blog.blocks = remove(blog.blocks, target)
+		writeResponse(w, 200, map[string]interface{}{})
+	case "GET likes":
+		if blog.owned {
+			s.serveLikes(w, r)
+		} else {
+			writeResponse(w, 200, map[string]interface{}{"liked_posts": []interface{}{}, "liked_count": 0})
+		}
+	case "GET posts/queue":
+		if !ownedOnly() {
+			return
+		}
+		queued := []*fakePost{}
+		for _, id := range blog.queue {
+			queued = append(queued, s.posts[id])
+		}
+		posts, _ := s.paginatePosts(r, queued)
+		writeResponse(w, 200, map[string]interface{}{"posts": posts})
+	case "POST posts/queue/reorder":
+		if !ownedOnly() {
+			return
+		}
+		id, _ := strconv.ParseInt(r.Form.Get("post_id"), 10, 64)
+		after, _ := strconv.ParseInt(r.Form.Get("insert_after"), 10, 64)
+		if !containsId(blog.queue, id) || after != 0 && !containsId(blog.queue, after) {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		queue := []int64{}
+		if after == 0 {
+			queue = append(queue, id)
+		}
+		for _, queued := range removeId(blog.queue, id) {
+			queue = append(queue, queued)
+			if queued == after {
+				queue = append(queue, id)
+			}
+		}
+		blog.queue = queue
+		writeResponse(w, 200, map[string]interface{}{})
+	case "POST posts/queue/shuffle":
+		if !ownedOnly() {
+			return
+		}
+		rand.Shuffle(len(blog.queue), func(i, j int) {
+			blog.queue[i], blog.queue[j] = blog.queue[j], blog.queue[i]
+		})
+		writeResponse(w, 200, map[string]interface{}{})
+	case "GET posts/draft", "GET posts/submission":
+		if !ownedOnly() {
+			return
+		}
+		state := endpoint[1]
+		beforeId, _ := strconv.ParseInt(r.Form.Get("before_id"), 10, 64)
+		posts, _ := s.filterPosts(r, func(post *fakePost) bool {
+			if beforeId != 0 && post.fields["id"].(int64) >= beforeId {
+				return false
+			}
+			return post.blog == name && post.fields["state"] == state
+		})
+		writeResponse(w, 200, map[string]interface{}{"posts": posts})
+	case "POST post":
+		if r.Form.Get("state") == "submission" {
+			post := s.create(name, r.Form)
+			post.fields["post_author"] = s.user
+			post.fields["is_submission"] = true
+			writeResponse(w, 201, map[string]interface{}{"id": post.fields["id"]})
+			return
+		}
+		if !ownedOnly() {
+			return
+		}
+		post := s.create(name, r.Form)
+		s.upload(post, r)
+		writeResponse(w, 201, map[string]interface{}{"id": post.fields["id"]})
+	case "POST pin", "DELETE pin":
+		if !ownedOnly() {
+			return
+		}
+		post := s.ownPost(name, r.Form.Get("id"))
+		if post == nil {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		if r.Method == "POST" {
+			for _, other := range s.posts {
+				if other.blog == name {
+					delete(other.fields, "is_pinned")
+				}
+			}
+			post.fields["is_pinned"] = true
+		} else {
+			delete(post.fields, "is_pinned")
+		}
+		writeResponse(w, 200, map[string]interface{}{})
+	case "POST ask":
+		asker := s.user
+		if r.Form.Get("anonymous") == "true" {
+			asker = "Anonymous"
+		}
+		post := s.create(name, map[string][]string{"type": {"answer"}, "state": {"submission"}, "question": {r.Form.Get("question")}})
+		post.fields["asking_name"] = asker
+		if asker != "Anonymous" {
+			post.fields["asking_url"] = "http://" + asker + ".tumblr.com/"
+		}
+		s.notify(blog, gotumblr.Notification{Type: gotumblr.NotificationAsk, From_tumblelog_name: asker, Target_post_id: strconv.FormatInt(post.fields["id"].(int64), 10)})
+		writeResponse(w, 201, map[string]interface{}{"id": post.fields["id"]})
+	case "POST post/edit":
+		if !ownedOnly() {
+			return
+		}
+		post := s.ownPost(name, r.Form.Get("id"))
+		if post == nil {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		s.update(post, r.Form)
+		writeResponse(w, 200, map[string]interface{}{"id": post.fields["id"]})
+	case "POST post/delete":
+		if !ownedOnly() {
+			return
+		}
+		post := s.ownPost(name, r.Form.Get("id"))
+		if post == nil {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		id := post.fields["id"].(int64)
+		delete(s.posts, id)
+		blog.queue = removeId(blog.queue, id)
+		s.likes = removeId(s.likes, id)
+		writeResponse(w, 200, map[string]interface{}{"id": id})
+	case "POST post/reblog":
+		if !ownedOnly() {
+			return
+		}
+		parent := s.postByKey(r.Form.Get("id"), r.Form.Get("reblog_key"))
+		if parent == nil {
+			writeMeta(w, 404, "Not Found")
+			return
+		}
+		post := s.reblog(name, parent, r.Form, r.Form.Get("comment"), false, nil)
+		writeResponse(w, 201, map[string]interface{}{"id": post.fields["id"]})
+	case "POST posts":
+		if !ownedOnly() {
+			return
+		}
+		var npf struct {
+			Content               []map[string]interface{}
+			Tags                  string
+			State                 string
+			Parent_tumblelog_uuid string
+			Parent_post_id        string
+			Reblog_key            string
+			Hide_trail            bool
+			Exclude_trail_items   []int
+		}
+		if err := json.NewDecoder(r.Body).Decode(&npf); err != nil {
+			writeMeta(w, 400, "Bad Request")
+			return
+		}
+		params := map[string][]string{}
+		if npf.Tags != "" {
+			params["tags"] = []string{npf.Tags}
+		}
+		if npf.State != "" {
+			params["state"] = []string{npf.State}
+		}
+		texts := []string{}
+		for _, block := range npf.Content {
+			if text, ok := block["text"].(string); ok && block["type"] == "text" {
+				texts = append(texts, text)
+			}
+		}
+		var post *fakePost
+		if npf.Parent_post_id != "" {
+			parent := s.postByKey(npf.Parent_post_id, npf.Reblog_key)
+			if parent == nil || npf.Parent_tumblelog_uuid != parent.blog && npf.Parent_tumblelog_uuid != s.blogs[parent.blog].info.Uuid {
+				writeMeta(w, 404, "Not Found")
+				return
+			}
+			post = s.reblog(name, parent, params, strings.Join(texts, "\n\n"), npf.Hide_trail, npf.Exclude_trail_items)
+		} else {
+			params["type"] = []string{"text"}
+			params["body"] = []string{strings.Join(texts, "\n\n")}
+			post = s.create(name, params)
+		}
+		post.fields["content"] = npf.Content
+		writeResponse(w, 201, map[string]interface{}{
+			"id":           strconv.FormatInt(post.fields["id"].(int64), 10),
+			"state":        post.fields["state"],
+			"display_text": "Posted to " + name,
+		})
+	default:
+		if r.Method == "GET" && len(endpoint) == 2 && endpoint[0] == "avatar" {
+			http.Redirect(w, r, s.URL+avatarPath(name, endpoint[1]), http.StatusMovedPermanently)
+			return
+		}
+		if len(endpoint) == 3 && endpoint[0] == "posts" && endpoint[2] == "mute" && (r.Method == "POST" || r.Method == "DELETE") {
+			if !ownedOnly() {
+				return
+			}
+			post := s.ownPost(name, endpoint[1])
+			if post == nil {
+				writeMeta(w, 404, "Not Found")
+				return
+			}
+			post.muted = r.Method == "POST"
+			writeResponse(w, 200, map[string]interface{}{})
+			return
+		}
+		if r.Method == "GET" && endpoint[0] == "posts" && len(endpoint) <= 2 {
+			postsType := ""
+			if len(endpoint) == 2 {
+				postsType = endpoint[1]
+			}
+			s.servePosts(w, r, name, postsType)
+			return
+		}
+		writeMeta(w, 404, "Not Found")
+	}
+}
+
+func (s *Server) servePosts(w http.ResponseWriter, r *http.Request, name, postsType string) {
+	id := r.Form.Get("id")
+	tag := r.Form.Get("tag")
+	posts, total := s.filterPosts(r, func(post *fakePost) bool {
+		if post.blog != name || post.fields["state"] != "published" {
+			return false
+		}
+		if postsType != "" && post.fields["type"] != postsType {
+			return false
+		}
+		if id != "" && strconv.FormatInt(post.fields["id"].(int64), 10) != id {
+			return false
+		}
+		if tag != "" && !contains(post.fields["tags"].([]string), tag) {
+			return false
+		}
+		return true
+	})
+	writeResponse(w, 200, map[string]interface{}{"blog": s.blogs[name].info, "posts": posts, "total_posts": total})
+}
+
+//Returns the rendered posts matching the filter, newest first and paginated with limit and offset,
+//along with the total number of matching posts.
+func (s *Server) filterPosts(r *http.Request, match func(*fakePost) bool) ([]map[string]interface{}, int) {
+	matched := []*fakePost{}
+	for _, post := range s.posts {
+		if match(post) {
+			matched = append(matched, post)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].fields["id"].(int64) > matched[j].fields["id"].(int64)
+	})
+	return s.paginatePosts(r, matched)
+}
+
+//Renders the page of the posts requested by the offset and limit parameters.
+//It also returns the total number of posts.
+func (s *Server) paginatePosts(r *http.Request, matched []*fakePost) ([]map[string]interface{}, int) {
+	total := len(matched)
+	offset, limit := pagination(r)
+	if offset > len(matched) {
+		offset = len(matched)
+	}
+	matched = matched[offset:]
+	if limit < len(matched) {
+		matched = matched[:limit]
+	}
+	posts := []map[string]interface{}{}
+	for _, post := range matched {
+		posts = append(posts, s.render(post))
+	}
+	return posts, total
+}
+
+func (s *Server) render(post *fakePost) map[string]interface{} {
+	rendered := map[string]interface{}{}
+	for key, value := range post.fields {
+		rendered[key] = value
+	}
+	rendered["liked"] = containsId(s.likes, post.fields["id"].(int64))
+	return rendered
+}
+
+func (s *Server) count(name, state string) int {
+	count := 0
+	for _, post := range s.posts {
+		if post.blog == name && post.fields["state"] == state {
+			count++
+		}
+	}
+	return count
+}
+
+//Creates a post on the blog from the parameters of a create request.
+//Describes the blog with the given name the way lists of blogs do.
+func (s *Server) followedBlog(name string) gotumblr.FollowedBlog {
+	info := s.blogs[name].info
+	return gotumblr.FollowedBlog{Name: name, Url: info.Url, Title: info.Title, Updated: info.Updated, Description: info.Description}
+}
+
+//Returns the number of posts of the given type on the server.
+func (s *Server) countType(postType string) int {
+	count := 0
+	for _, post := range s.posts {
+		if post.fields["type"] == postType {
+			count++
+		}
+	}
+	return count
+}
+
+//Reblogs the parent post to the blog with the given name.
+//The trail of the reblog is the one of the parent followed by the parent,
+//without the items with the excluded indexes, or empty if hideTrail is true.
+func (s *Server) reblog(name string, parent *fakePost, params map[string][]string, comment string, hideTrail bool, exclude []int) *fakePost {
+	post := &fakePost{blog: name, fields: map[string]interface{}{}}
+	for key, value := range parent.fields {
+		post.fields[key] = value
+	}
+	delete(post.fields, "is_pinned")
+	parentId := strconv.FormatInt(parent.fields["id"].(int64), 10)
+	trail := []map[string]interface{}{}
+	if !hideTrail {
+		parentTrail, _ := parent.fields["trail"].([]map[string]interface{})
+		parentTrail = append(parentTrail, map[string]interface{}{
+			"blog": map[string]interface{}{"name": parent.blog},
+			"post": map[string]interface{}{"id": parentId},
+		})
+		for i, item := range parentTrail {
+			excluded := false
+			for _, index := range exclude {
+				excluded = excluded || index == i
+			}
+			if !excluded {
+				trail = append(trail, item)
+			}
+		}
+	}
+	post.fields["trail"] = trail
+	post.fields["reblog"] = map[string]interface{}{"comment": comment}
+	post.fields["reblogged_from_id"] = parentId
+	post.fields["reblogged_from_name"] = parent.blog
+	s.store(post, params)
+	return post
+}
+
+func (s *Server) create(name string, params map[string][]string) *fakePost {
+	post := &fakePost{blog: name, fields: map[string]interface{}{"tags": []string{}, "format": "html", "note_count": int64(0)}}
+	s.store(post, params)
+	return post
+}
+
+func (s *Server) store(post *fakePost, params map[string][]string) {
+	s.nextId++
+	id := s.nextId
+	now := time.Now().UTC()
+	post.fields["id"] = id
+	post.fields["blog_name"] = post.blog
+	info := s.blogs[post.blog].info
+	post.fields["blog"] = gotumblr.PostBlog{Name: info.Name, Title: info.Title, Url: info.Url, Uuid: info.Uuid}
+	post.fields["post_url"] = fmt.Sprintf("http://%s.tumblr.com/post/%d", post.blog, id)
+	post.fields["reblog_key"] = fmt.Sprintf("key%d", id)
+	post.fields["timestamp"] = now.Unix()
+	post.fields["date"] = now.Format("2006-01-02 15:04:05 GMT")
+	post.fields["state"] = "published"
+	s.posts[id] = post
+	s.update(post, params)
+}
+
+//Applies the parameters of a create or edit request to the post.
+func (s *Server) update(post *fakePost, params map[string][]string) {
+	if postType, ok := params["type"]; ok {
+		post.fields["type"] = postType[0]
+	}
+	for key, values := range params {
+		value := values[0]
+		switch key {
+		case "id", "type", "reblog_key", "api_key", "comment", "mute_length":
+		case "has_community_label":
+			post.fields[key] = value == "true"
+			if value != "true" {
+				delete(post.fields, "community_label_categories")
+			}
+		case "tags":
+			tags := []string{}
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+			post.fields["tags"] = tags
+		case "state":
+			if value == "queue" {
+				value = "queued"
+			}
+			post.fields["state"] = value
+		case "date":
+			for _, format := range gotumblr.DateFormats {
+				if date, err := time.Parse(format, value); err == nil {
+					post.fields["timestamp"] = date.Unix()
+					post.fields["date"] = date.UTC().Format("2006-01-02 15:04:05 GMT")
+					break
+				}
+			}
+		case "slug":
+			post.fields["slug"] = value
+			post.fields["post_url"] = fmt.Sprintf("http://%s.tumblr.com/post/%d/%s", post.blog, post.fields["id"], value)
+		case "publish_on":
+			if publishOn, err := time.Parse(time.RFC3339, value); err == nil {
+				post.fields["scheduled_publish_time"] = publishOn.Unix()
+			}
+		case "quote":
+			post.fields["text"] = value
+		case "conversation":
+			post.fields["body"] = value
+			post.fields["dialogue"] = dialogue(value)
+		case "external_url":
+			post.fields["audio_url"] = value
+		case "embed":
+			post.fields["player"] = []gotumblr.PlayerInfo{{Width: 500, Embed_code: value}}
+		case "source":
+			if post.fields["type"] == "photo" {
+				post.fields["photos"] = []gotumblr.PhotoObject{{Alt_sizes: []gotumblr.AltSize{{Width: 500, Height: 500, Url: value}}}}
+			} else {
+				post.fields["source"] = value
+			}
+		default:
+			if strings.HasPrefix(key, "community_label_categories") {
+				post.fields["community_label_categories"] = formValues(&http.Request{Form: params}, "community_label_categories")
+			} else if !strings.HasPrefix(key, "oauth_") {
+				post.fields[key] = value
+			}
+		}
+	}
+	blog := s.blogs[post.blog]
+	id := post.fields["id"].(int64)
+	if post.fields["state"] == "queued" {
+		if !containsId(blog.queue, id) {
+			blog.queue = append(blog.queue, id)
+		}
+	} else {
+		blog.queue = removeId(blog.queue, id)
+		delete(post.fields, "scheduled_publish_time")
+	}
+}
+
+//Stores the files uploaded with a create request as media and makes them the media of the post.
+//Files are uploaded as data, or as data[0], data[1] and so on for photosets.
+func (s *Server) upload(post *fakePost, r *http.Request) {
+	if r.MultipartForm == nil {
+		return
+	}
+	files := r.MultipartForm.File["data"]
+	for i := 0; len(r.MultipartForm.File[fmt.Sprintf("data[%d]", i)]) != 0; i++ {
+		files = append(files, r.MultipartForm.File[fmt.Sprintf("data[%d]", i)][0])
+	}
+	urls := []string{}
+	for i, header := range files {
+		file, err := header.Open()
+		if err != nil {
+			continue
+		}
+		content, _ := ioutil.ReadAll(file)
+		file.Close()
+		name := fmt.Sprintf("upload_%d_%d%s", post.fields["id"], i, path.Ext(header.Filename))
+		s.media[name] = content
+		urls = append(urls, s.URL+"/media/"+name)
+	}
+	if len(urls) == 0 {
+		return
+	}
+	switch post.fields["type"] {
+	case "photo":
+		photos := []gotumblr.PhotoObject{}
+		for _, url := range urls {
+			photos = append(photos, gotumblr.PhotoObject{Alt_sizes: []gotumblr.AltSize{{Width: 500, Height: 500, Url: url}}})
+		}
+		post.fields["photos"] = photos
+	case "audio":
+		post.fields["audio_url"] = urls[0]
+	case "video":
+		post.fields["video_url"] = urls[0]
+	}
+}
+
+func (s *Server) ownPost(name, id string) *fakePost {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil
+	}
+	post, ok := s.posts[n]
+	if !ok || post.blog != name {
+		return nil
+	}
+	return post
+}
+
+func (s *Server) postByKey(id, reblogKey string) *fakePost {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil
+	}
+	post, ok := s.posts[n]
+	if !ok || post.fields["reblog_key"] != reblogKey {
+		return nil
+	}
+	return post
+}
+
+func dialogue(conversation string) []gotumblr.DialogueInfo {
+	lines := []gotumblr.DialogueInfo{}
+	for _, line := range strings.Split(conversation, "\n") {
+		if i := strings.Index(line, ":"); i != -1 {
+			name := strings.TrimSpace(line[:i])
+			lines = append(lines, gotumblr.DialogueInfo{Name: name, Label: name + ":", Phrase: strings.TrimSpace(line[i+1:])})
+		}
+	}
+	return lines
+}
+
+//A 1x1 transparent PNG, served as the image of every avatar.
+var avatarImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00\x1f\x15\xc4\x89\x00\x00\x00\rIDATx\x9cc\xf8\x0f\x00\x00\x01\x01\x00\x05\x18\xd8N\x00\x00\x00\x00IEND\xaeB`\x82")
+
+func avatarPath(name, size string) string {
+	return fmt.Sprintf("/media/avatar_%s_%s.png", name, size)
+}
+
+//Reports whether the path is the one of a media file or an avatar, which are requested without signatures.
+func isMedia(path string) bool {
+	return strings.HasPrefix(path, "/media/") || strings.HasPrefix(path, "/v2/blog/") && strings.Contains(path, "/avatar")
+}
+
+//The number of notifications the server returns at a time.
+const notificationsPageSize = 20
+
+func pagination(r *http.Request) (offset, limit int) {
+	offset, _ = strconv.Atoi(r.Form.Get("offset"))
+	limit, err := strconv.Atoi(r.Form.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, limit
+}
+
+func paginateBlogs(blogs []gotumblr.FollowedBlog, r *http.Request) []gotumblr.FollowedBlog {
+	offset, limit := pagination(r)
+	if offset > len(blogs) {
+		offset = len(blogs)
+	}
+	blogs = blogs[offset:]
+	if limit < len(blogs) {
+		blogs = blogs[:limit]
+	}
+	return blogs
+}
+
+func writeMeta(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"meta":     map[string]interface{}{"status": status, "msg": msg},
+		"response": []interface{}{},
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"meta":     map[string]interface{}{"status": status, "msg": http.StatusText(status)},
+		"response": response,
+	})
+}
+
+//Returns the values of the form array with the given name,
+//sent either as name, name[] or name[0], name[1] and so on.
+func formValues(r *http.Request, name string) []string {
+	values := append([]string{}, r.Form[name]...)
+	values = append(values, r.Form[name+"[]"]...)
+	for i := 0; ; i++ {
+		value, ok := r.Form[fmt.Sprintf("%s[%d]", name, i)]
+		if !ok {
+			break
+		}
+		values = append(values, value...)
+	}
+	return values
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func containsId(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeId(ids []int64, id int64) []int64 {
+	result := []int64{}
+	for _, i := range ids {
+		if i != id {
+			result = append(result, i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,830 @@
+package gotumblrtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MariaTerzieva/gotumblr"
+)
+
+func newServer() *Server {
+	s := NewServer("mgterzieva", "consumer_key", "consumer_secret", "token", "token_secret")
+	s.AddBlog("mgterzieva", true)
+	s.AddBlog("thehungergames", false)
+	return s
+}
+
+func formatId(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func TestCreateTextShowsInPosts(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	result, err := client.CreateText("mgterzieva", map[string]string{"body": "Hello, hello!", "tags": "golang,tumblr"})
+	if err != nil || result.ID != "1001" {
+		t.Fatalf("CreateText returned %+v, %+v, want 1001", result, err)
+	}
+	posts := client.Posts("mgterzieva", "", map[string]string{"tag": "golang"})
+	if posts.Total_posts != 1 {
+		t.Fatalf("Total_posts returned %v, want %v", posts.Total_posts, 1)
+	}
+	var post gotumblr.TextPost
+	json.Unmarshal(posts.Posts[0], &post)
+	if post.Body != "Hello, hello!" || post.PostType != "text" || !reflect.DeepEqual(post.Tags, []string{"golang", "tumblr"}) {
+		t.Errorf("Posts returned %+v", post)
+	}
+}
+
+func TestDraftsAndQueue(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateQuote("mgterzieva", map[string]string{"quote": "A happy heart.", "state": "draft"})
+	client.CreateQuote("mgterzieva", map[string]string{"quote": "Proverbs 15:13", "state": "queue"})
+
+	if drafts := client.Drafts("mgterzieva", map[string]string{}); len(drafts.Posts) != 1 {
+		t.Errorf("Drafts returned %v posts, want %v", len(drafts.Posts), 1)
+	}
+	if queue := client.Queue("mgterzieva", map[string]string{}); len(queue.Posts) != 1 {
+		t.Errorf("Queue returned %v posts, want %v", len(queue.Posts), 1)
+	}
+	if posts := client.Posts("mgterzieva", "", map[string]string{}); posts.Total_posts != 0 {
+		t.Errorf("Total_posts returned %v, want %v", posts.Total_posts, 0)
+	}
+}
+
+func TestEditAndDelete(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	var post gotumblr.TextPost
+	json.Unmarshal(client.Posts("mgterzieva", "", map[string]string{}).Posts[0], &post)
+	id := formatId(post.Id)
+
+	if _, err := client.EditPost("mgterzieva", map[string]string{"id": id, "body": "Hello, world"}); err != nil {
+		t.Fatalf("EditPost returned %+v, want %+v", err, nil)
+	}
+	json.Unmarshal(client.Posts("mgterzieva", "", map[string]string{}).Posts[0], &post)
+	if post.Body != "Hello, world" {
+		t.Errorf("Body after EditPost = %v, want %v", post.Body, "Hello, world")
+	}
+	if err := client.DeletePost("mgterzieva", id); err != nil {
+		t.Fatalf("DeletePost returned %+v, want %+v", err, nil)
+	}
+	if posts := client.Posts("mgterzieva", "", map[string]string{}); posts.Total_posts != 0 {
+		t.Errorf("Total_posts after DeletePost = %v, want %v", posts.Total_posts, 0)
+	}
+}
+
+func TestLikeShowsInLikes(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	var post gotumblr.BasePost
+	json.Unmarshal(client.Posts("mgterzieva", "", map[string]string{}).Posts[0], &post)
+
+	if err := client.Like(formatId(post.Id), "wrong"); !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("Like with a wrong reblog key returned %+v, want Not Found", err)
+	}
+	if err := client.Like(formatId(post.Id), post.Reblog_key); err != nil {
+		t.Fatalf("Like returned %+v, want %+v", err, nil)
+	}
+	likes := client.Likes(map[string]string{})
+	if likes.Liked_count != 1 {
+		t.Fatalf("Liked_count returned %v, want %v", likes.Liked_count, 1)
+	}
+	var liked gotumblr.BasePost
+	json.Unmarshal(likes.Liked_posts[0], &liked)
+	if liked.Id != post.Id || !liked.Liked {
+		t.Errorf("Likes returned %+v, want the liked post %v", liked, post.Id)
+	}
+
+	client.Unlike(formatId(post.Id), post.Reblog_key)
+	if likes := client.Likes(map[string]string{}); likes.Liked_count != 0 {
+		t.Errorf("Liked_count after Unlike returned %v, want %v", likes.Liked_count, 0)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	if err := client.Follow("http://nosuchblog.tumblr.com"); !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("Follow returned %+v, want Not Found", err)
+	}
+	if err := client.Follow("http://thehungergames.tumblr.com"); err != nil {
+		t.Fatalf("Follow returned %+v, want %+v", err, nil)
+	}
+	following := client.Following(map[string]string{})
+	if following.Total_blogs != 1 || following.Blogs[0].Name != "thehungergames" {
+		t.Errorf("Following returned %+v", following)
+	}
+	if info := client.Info(); info.User.Following != 1 || len(info.User.Blogs) != 1 {
+		t.Errorf("Info returned %+v", info)
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+
+	client := gotumblr.NewTumblrRestClient("consumer_key", "wrong_secret", "token", "token_secret", "", s.URL)
+	if err := client.Follow("thehungergames"); !reflect.DeepEqual(err, errors.New("Unauthorized")) {
+		t.Errorf("Follow with a wrong secret returned %+v, want Unauthorized", err)
+	}
+	if err := s.Client().Follow("thehungergames"); err != nil {
+		t.Errorf("Follow returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	s.FailNext("POST", "/v2/blog/mgterzieva/post", 503, "Service Unavailable")
+	_, err := client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	if !reflect.DeepEqual(err, errors.New("Service Unavailable")) {
+		t.Errorf("CreateText returned %+v, want Service Unavailable", err)
+	}
+	if _, err := client.CreateText("mgterzieva", map[string]string{"body": "Hello"}); err != nil {
+		t.Errorf("CreateText returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestSetRateLimit(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	s.SetRateLimit(1)
+	if err := client.Follow("thehungergames"); err != nil {
+		t.Errorf("Follow returned %+v, want %+v", err, nil)
+	}
+	if err := client.Unfollow("thehungergames"); !reflect.DeepEqual(err, errors.New("Limit Exceeded")) {
+		t.Errorf("Unfollow returned %+v, want Limit Exceeded", err)
+	}
+}
+
+func TestAvatar(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	image, err := client.AvatarImage(context.Background(), "mgterzieva", 128)
+	if err != nil {
+		t.Fatalf("AvatarImage returned %+v, want %+v", err, nil)
+	}
+	content, _ := image.Bytes()
+	if image.Url != s.URL+"/media/avatar_mgterzieva_128.png" || image.Content_type != "image/png" || len(content) == 0 {
+		t.Errorf("AvatarImage returned %+v", image)
+	}
+}
+
+func TestFilteredTagsAndContent(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	if err := client.AddFilteredTags("spoilers", "the hunger games, part 2"); err != nil {
+		t.Fatalf("AddFilteredTags returned %+v, want %+v", err, nil)
+	}
+	if err := client.RemoveFilteredTag("spoilers"); err != nil {
+		t.Fatalf("RemoveFilteredTag returned %+v, want %+v", err, nil)
+	}
+	if tags := client.FilteredTags().Filtered_tags; !reflect.DeepEqual(tags, []string{"the hunger games, part 2"}) {
+		t.Errorf("FilteredTags returned %+v", tags)
+	}
+
+	client.AddFilteredContent("mockingjay", "district 12")
+	if err := client.RemoveFilteredContent("mockingjay"); err != nil {
+		t.Fatalf("RemoveFilteredContent returned %+v, want %+v", err, nil)
+	}
+	if err := client.RemoveFilteredContent("mockingjay"); err == nil {
+		t.Errorf("RemoveFilteredContent returned %+v, want Not Found", err)
+	}
+	if content := client.FilteredContent().Filtered_content; !reflect.DeepEqual(content, []string{"district 12"}) {
+		t.Errorf("FilteredContent returned %+v", content)
+	}
+}
+
+func TestUserLimits(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	limits := client.UserLimits().User
+	if limits.Posts.Limit != 250 || limits.Posts.Remaining != 249 || limits.Posts.Reset_at == 0 {
+		t.Errorf("UserLimits returned %+v", limits.Posts)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddBlog("spam", false)
+	blog := s.Client().Blog("mgterzieva")
+
+	if err := blog.BulkBlock([]string{"spam", "thehungergames"}); err != nil {
+		t.Fatalf("BulkBlock returned %+v, want %+v", err, nil)
+	}
+	if err := blog.Unblock("thehungergames"); err != nil {
+		t.Fatalf("Unblock returned %+v, want %+v", err, nil)
+	}
+	if err := blog.Block("nosuchblog"); err == nil {
+		t.Errorf("Block returned %+v, want Not Found", err)
+	}
+	it := blog.BlocksIterator(context.Background())
+	names := []string{}
+	for it.Next() {
+		names = append(names, it.Blog().Name)
+	}
+	if it.Err() != nil || !reflect.DeepEqual(names, []string{"spam"}) {
+		t.Errorf("BlocksIterator returned %v, %v", names, it.Err())
+	}
+}
+
+func TestBlogFollowingAndFollowedBy(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddBlog("mgterzieva-art", true)
+	client := s.Client()
+
+	client.Follow("thehungergames")
+	client.Follow("mgterzieva-art")
+	following := client.BlogFollowing("mgterzieva", map[string]string{})
+	if following.Total_blogs != 2 || following.Blogs[0].Name != "thehungergames" {
+		t.Errorf("BlogFollowing returned %+v", following)
+	}
+	if !client.FollowedBy("mgterzieva-art", "mgterzieva").Followed_by {
+		t.Errorf("FollowedBy returned false, want true")
+	}
+	if client.FollowedBy("mgterzieva-art", "thehungergames").Followed_by {
+		t.Errorf("FollowedBy returned true, want false")
+	}
+}
+
+func TestQueueManagement(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	blog := s.Client().Blog("mgterzieva")
+
+	for _, body := range []string{"first", "second", "third"} {
+		blog.CreateText(map[string]string{"body": body, "state": "queue"})
+	}
+	blog.CreateText(map[string]string{"body": "draft", "state": "draft"})
+	queueIds := func() []int64 {
+		ids := []int64{}
+		for _, post := range blog.QueuedPosts(map[string]string{}) {
+			ids = append(ids, post.Id)
+		}
+		return ids
+	}
+	if ids := queueIds(); !reflect.DeepEqual(ids, []int64{1001, 1002, 1003}) {
+		t.Fatalf("QueuedPosts returned %v", ids)
+	}
+
+	if err := blog.ReorderQueue("1003", "0"); err != nil {
+		t.Fatalf("ReorderQueue returned %+v, want %+v", err, nil)
+	}
+	if err := blog.ReorderQueue("1001", "1002"); err != nil {
+		t.Fatalf("ReorderQueue returned %+v, want %+v", err, nil)
+	}
+	if ids := queueIds(); !reflect.DeepEqual(ids, []int64{1003, 1002, 1001}) {
+		t.Errorf("QueuedPosts after ReorderQueue returned %v", ids)
+	}
+	if err := blog.ReorderQueue("1004", "0"); err == nil {
+		t.Errorf("ReorderQueue of a draft returned %+v, want Not Found", err)
+	}
+
+	publishOn := time.Date(2014, 10, 31, 12, 0, 0, 0, time.UTC)
+	if err := blog.SchedulePost("1004", publishOn); err != nil {
+		t.Fatalf("SchedulePost returned %+v, want %+v", err, nil)
+	}
+	posts := blog.QueuedPosts(map[string]string{})
+	if len(posts) != 4 || posts[3].Id != 1004 || !posts[3].PublishTime().Equal(publishOn) {
+		t.Errorf("QueuedPosts after SchedulePost returned %+v", posts)
+	}
+
+	if err := blog.ShuffleQueue(); err != nil {
+		t.Errorf("ShuffleQueue returned %+v, want %+v", err, nil)
+	}
+	if ids := queueIds(); len(ids) != 4 {
+		t.Errorf("QueuedPosts after ShuffleQueue returned %v", ids)
+	}
+}
+
+func TestQueueDraft(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "draft", "state": "draft"})
+	if err := client.QueueDraft("mgterzieva", "1001"); err != nil {
+		t.Fatalf("QueueDraft returned %+v, want %+v", err, nil)
+	}
+	if drafts := client.Drafts("mgterzieva", map[string]string{}); len(drafts.Posts) != 0 {
+		t.Errorf("Drafts returned %v posts, want %v", len(drafts.Posts), 0)
+	}
+	if queue := client.QueuedPosts("mgterzieva", map[string]string{}); len(queue) != 1 || queue[0].State != "queued" {
+		t.Errorf("QueuedPosts returned %+v", queue)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	client.Like("1001", "key1001")
+	for i := 0; i < 25; i++ {
+		s.AddNotification("mgterzieva", gotumblr.Notification{Type: gotumblr.NotificationReply, Timestamp: int64(1000 + i)})
+	}
+
+	likes, err := client.Notifications(context.Background(), "mgterzieva", gotumblr.NotificationOptions{Types: []gotumblr.NotificationType{gotumblr.NotificationLike}})
+	if err != nil || len(likes.Notifications) != 1 || likes.Notifications[0].Target_post_id != "1001" {
+		t.Fatalf("Notifications returned %+v, %+v", likes, err)
+	}
+	first, _ := client.Notifications(context.Background(), "mgterzieva", gotumblr.NotificationOptions{})
+	second, _ := client.Notifications(context.Background(), "mgterzieva", gotumblr.NotificationOptions{Before: first.Before()})
+	if len(first.Notifications) != 20 || len(second.Notifications) != 6 || second.Before() != 0 {
+		t.Errorf("Notifications returned pages of %v and %v", len(first.Notifications), len(second.Notifications))
+	}
+	if _, err := client.Notifications(context.Background(), "thehungergames", gotumblr.NotificationOptions{}); err == nil {
+		t.Errorf("Notifications of a blog that is not owned returned %+v, want Forbidden", err)
+	}
+}
+
+func TestNotificationStream(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddBlog("mgterzieva-art", true)
+	client := s.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := client.NotificationStream(ctx, "mgterzieva-art", time.Now().Add(-time.Minute), time.Millisecond, gotumblr.NotificationFollow)
+	client.Follow("mgterzieva-art")
+	if !stream.Next() {
+		t.Fatalf("Next returned false, %+v", stream.Err())
+	}
+	if notification := stream.Notification(); notification.Type != gotumblr.NotificationFollow || notification.From_tumblelog_name != "mgterzieva" {
+		t.Errorf("Notification returned %+v", notification)
+	}
+}
+
+func TestAsksAndSubmissions(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	blog := s.Client().Blog("mgterzieva")
+
+	if err := blog.SendAsk("Which district?", true); err != nil {
+		t.Fatalf("SendAsk returned %+v, want %+v", err, nil)
+	}
+	if _, err := blog.SubmitPost("text", map[string]string{"title": "Fan art", "body": "Look!"}); err != nil {
+		t.Fatalf("SubmitPost returned %+v, want %+v", err, nil)
+	}
+	blog.SubmitPost("text", map[string]string{"body": "Spam"})
+
+	asks := blog.Asks(map[string]string{})
+	if len(asks) != 1 || asks[0].Question != "Which district?" || !asks[0].Anonymous() {
+		t.Fatalf("Asks returned %+v", asks)
+	}
+	if _, err := blog.AnswerAsk(formatId(asks[0].Id), "District 12", map[string]string{}); err != nil {
+		t.Fatalf("AnswerAsk returned %+v, want %+v", err, nil)
+	}
+	if _, err := blog.PublishSubmission("1002", map[string]string{"title": "Fan art!"}); err != nil {
+		t.Fatalf("PublishSubmission returned %+v, want %+v", err, nil)
+	}
+	if err := blog.DeclineSubmission("1003"); err != nil {
+		t.Fatalf("DeclineSubmission returned %+v, want %+v", err, nil)
+	}
+	if submissions := blog.PendingSubmissions(map[string]string{}); len(submissions) != 0 {
+		t.Errorf("PendingSubmissions returned %+v, want none", submissions)
+	}
+
+	posts := blog.Posts("", map[string]string{})
+	var answer gotumblr.AnswerPost
+	var text gotumblr.TextPost
+	json.Unmarshal(posts.Posts[0], &text)
+	json.Unmarshal(posts.Posts[1], &answer)
+	if posts.Total_posts != 2 || answer.Answer != "District 12" || text.Title != "Fan art!" {
+		t.Errorf("Posts returned %+v and %+v", answer, text)
+	}
+}
+
+func TestPinMuteAndCommunityLabels(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+	blog := client.Blog("mgterzieva")
+
+	options := map[string]string{"body": "The arena"}
+	gotumblr.SetCommunityLabel(options, gotumblr.CommunityLabelViolence)
+	blog.CreateText(options)
+	blog.CreateText(map[string]string{"body": "Hello"})
+
+	if err := blog.PinPost("1001"); err != nil {
+		t.Fatalf("PinPost returned %+v, want %+v", err, nil)
+	}
+	blog.PinPost("1002")
+	var first, second gotumblr.BasePost
+	posts := blog.Posts("", map[string]string{}).Posts
+	json.Unmarshal(posts[0], &second)
+	json.Unmarshal(posts[1], &first)
+	if first.Is_pinned || !second.Is_pinned {
+		t.Errorf("Is_pinned returned %v and %v, want false and true", first.Is_pinned, second.Is_pinned)
+	}
+	if !first.Has_community_label || !reflect.DeepEqual(first.Community_label_categories, []gotumblr.CommunityLabelCategory{gotumblr.CommunityLabelViolence}) {
+		t.Errorf("Posts returned %+v", first)
+	}
+	if err := blog.UnpinPost("1002"); err != nil {
+		t.Errorf("UnpinPost returned %+v, want %+v", err, nil)
+	}
+
+	if err := blog.MutePost("1001", 0); err != nil {
+		t.Fatalf("MutePost returned %+v, want %+v", err, nil)
+	}
+	client.Like("1001", "key1001")
+	if err := blog.UnmutePost("1001"); err != nil {
+		t.Fatalf("UnmutePost returned %+v, want %+v", err, nil)
+	}
+	client.Like("1002", "key1002")
+	notifications, _ := blog.Notifications(context.Background(), gotumblr.NotificationOptions{})
+	if len(notifications.Notifications) != 1 || notifications.Notifications[0].Target_post_id != "1002" {
+		t.Errorf("Notifications returned %+v", notifications.Notifications)
+	}
+	if err := blog.MutePost("9999", time.Hour); err == nil {
+		t.Errorf("MutePost returned %+v, want Not Found", err)
+	}
+}
+
+func TestReblogPost(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddBlog("mgterzieva-art", true)
+	client := s.Client()
+
+	client.CreateText("mgterzieva", map[string]string{"body": "Hello"})
+	var original gotumblr.TextPost
+	json.Unmarshal(client.Posts("mgterzieva", "", map[string]string{}).Posts[0], &original)
+
+	result, err := client.ReblogPost(context.Background(), "mgterzieva-art", original, gotumblr.ReblogOptions{Comment: "Hi!", Tags: []string{"hello"}})
+	want := gotumblr.PostResult{ID: "1002", State: "published", DisplayText: "Posted to mgterzieva-art"}
+	if err != nil || result != want {
+		t.Fatalf("ReblogPost returned %+v, %+v, want %+v", result, err, want)
+	}
+	var reblog gotumblr.TextPost
+	json.Unmarshal(client.Posts("mgterzieva-art", "", map[string]string{}).Posts[0], &reblog)
+	if reblog.Body != "Hello" || !reflect.DeepEqual(reblog.Tags, []string{"hello"}) || reblog.Blog.Uuid != "t:mgterzieva-art" {
+		t.Errorf("Posts returned %+v", reblog)
+	}
+
+	result, err = client.ReblogPost(context.Background(), "mgterzieva", reblog, gotumblr.ReblogOptions{ExcludeTrailItems: []int{0}})
+	if err != nil {
+		t.Fatalf("ReblogPost returned %+v, want %+v", err, nil)
+	}
+	var trail struct {
+		Trail []struct {
+			Blog struct{ Name string }
+		}
+	}
+	response := client.Posts("mgterzieva", "", map[string]string{"id": result.ID})
+	json.Unmarshal(response.Posts[0], &trail)
+	if len(trail.Trail) != 1 || trail.Trail[0].Blog.Name != "mgterzieva-art" {
+		t.Errorf("the trail of the reblog is %+v", trail.Trail)
+	}
+
+	original.Reblog_key = "wrong"
+	if _, err := client.ReblogPost(context.Background(), "mgterzieva", original, gotumblr.ReblogOptions{}); err == nil {
+		t.Errorf("ReblogPost with a wrong reblog key returned %+v, want Not Found", err)
+	}
+}
+
+func TestBulk(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	blog := s.Client().Blog("mgterzieva")
+	ctx := context.Background()
+
+	for i := 0; i < 25; i++ {
+		blog.CreateText(map[string]string{"body": "Buy now!", "tags": "spam"})
+	}
+	blog.CreateText(map[string]string{"body": "Hello", "tags": "hello"})
+	for i := 0; i < 22; i++ {
+		blog.CreateText(map[string]string{"body": "Soon", "state": "draft"})
+	}
+
+	spam := gotumblr.PostSelector{Tag: "spam", States: []string{"published"}}
+	report, err := blog.Bulk(ctx, spam, gotumblr.BulkAction{Delete: true}, gotumblr.BulkOptions{DryRun: true})
+	if err != nil || len(report.Items) != 25 || report.Count(gotumblr.BulkPlanned) != 25 {
+		t.Fatalf("Bulk dry run returned %+v, %+v", report, err)
+	}
+	if posts := blog.Posts("", map[string]string{}); posts.Total_posts != 26 {
+		t.Errorf("Total_posts after a dry run returned %v, want %v", posts.Total_posts, 26)
+	}
+
+	events := 0
+	report, err = blog.Bulk(ctx, spam, gotumblr.BulkAction{Delete: true}, gotumblr.BulkOptions{
+		Concurrency: 3,
+		Interval:    time.Millisecond,
+		Progress: func(event gotumblr.BulkEvent) {
+			events++
+			if event.Done != events || event.Total != 25 {
+				t.Errorf("Progress got %+v after %v events", event, events)
+			}
+		},
+	})
+	if err != nil || report.Count(gotumblr.BulkSucceeded) != 25 || events != 25 {
+		t.Fatalf("Bulk delete returned %+v, %+v after %v events", report, err, events)
+	}
+	if posts := blog.Posts("", map[string]string{}); posts.Total_posts != 1 {
+		t.Errorf("Total_posts after deleting spam returned %v, want %v", posts.Total_posts, 1)
+	}
+
+	drafts := gotumblr.PostSelector{States: []string{"draft"}}
+	queue := gotumblr.BulkAction{AddTags: []string{"soon"}, State: "queue"}
+	s.FailNext("POST", "/v2/blog/mgterzieva/post/edit", 500, "Internal Server Error")
+	report, err = blog.Bulk(ctx, drafts, queue, gotumblr.BulkOptions{})
+	if err != nil || report.Count(gotumblr.BulkSucceeded) != 21 || report.Count(gotumblr.BulkFailed) != 1 {
+		t.Fatalf("Bulk queue returned %+v, %+v", report, err)
+	}
+
+	other := gotumblr.BulkAction{AddTags: []string{"later"}, State: "queue"}
+	if _, err := blog.Bulk(ctx, drafts, other, gotumblr.BulkOptions{Resume: report}); err == nil {
+		t.Errorf("Bulk resuming another action returned %+v, want an error", err)
+	}
+	otherBlog := *report
+	otherBlog.Blog = "staff"
+	if _, err := blog.Bulk(ctx, drafts, queue, gotumblr.BulkOptions{Resume: &otherBlog}); err == nil {
+		t.Errorf("Bulk resuming the report of another blog returned %+v, want an error", err)
+	}
+	report, err = blog.Bulk(ctx, gotumblr.PostSelector{Tag: "soon"}, queue, gotumblr.BulkOptions{Resume: report})
+	if err != nil || len(report.Items) != 21 || report.Count(gotumblr.BulkSkipped) != 21 {
+		t.Fatalf("resumed Bulk returned %+v, %+v", report, err)
+	}
+	report, err = blog.Bulk(ctx, drafts, queue, gotumblr.BulkOptions{Resume: report})
+	if err != nil || len(report.Items) != 1 || report.Count(gotumblr.BulkSucceeded) != 1 {
+		t.Fatalf("resumed Bulk returned %+v, %+v", report, err)
+	}
+	if queued := blog.QueuedPosts(map[string]string{}); len(queued) != 20 || queued[0].Tags[0] != "soon" {
+		t.Errorf("QueuedPosts returned %v posts", len(queued))
+	}
+	if drafts := blog.Drafts(map[string]string{}); len(drafts.Posts) != 0 {
+		t.Errorf("Drafts returned %v posts, want %v", len(drafts.Posts), 0)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := blog.Bulk(canceled, gotumblr.PostSelector{}, gotumblr.BulkAction{Delete: true}, gotumblr.BulkOptions{}); err == nil {
+		t.Errorf("Bulk with a canceled context returned %+v, want an error", err)
+	}
+}
+
+func TestRenameTagsAndUndo(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	blog := s.Client().Blog("mgterzieva")
+	ctx := context.Background()
+
+	blog.CreateText(map[string]string{"body": "Published", "tags": "wip,colour"})
+	blog.CreateText(map[string]string{"body": "Queued", "tags": "Color,cats", "state": "queue"})
+	blog.CreateText(map[string]string{"body": "Draft", "tags": "WIP", "state": "draft"})
+	blog.CreateText(map[string]string{"body": "Other", "tags": "cats"})
+
+	var undoLog bytes.Buffer
+	renames := map[string]string{"wip": "work-in-progress", "colour": "color", "Color": "color"}
+	report, err := blog.RenameTags(ctx, renames, gotumblr.BulkOptions{UndoLog: &undoLog})
+	if err != nil || len(report.Items) != 3 || report.Count(gotumblr.BulkSucceeded) != 3 {
+		t.Fatalf("RenameTags returned %+v, %+v", report, err)
+	}
+	tagsOf := func(posts []json.RawMessage) [][]string {
+		tags := [][]string{}
+		for _, raw := range posts {
+			var post gotumblr.TextPost
+			json.Unmarshal(raw, &post)
+			tags = append(tags, post.Tags)
+		}
+		return tags
+	}
+	var published gotumblr.TextPost
+	json.Unmarshal(blog.Posts("", map[string]string{"id": "1001"}).Posts[0], &published)
+	if published.Body != "Published" || !reflect.DeepEqual(published.Tags, []string{"work-in-progress", "color"}) {
+		t.Errorf("the renamed post is %+v", published)
+	}
+	if tags := tagsOf(blog.Queue(map[string]string{}).Posts); !reflect.DeepEqual(tags, [][]string{{"color", "cats"}}) {
+		t.Errorf("the tags of the queue are %v", tags)
+	}
+	if tags := tagsOf(blog.Drafts(map[string]string{}).Posts); !reflect.DeepEqual(tags, [][]string{{"work-in-progress"}}) {
+		t.Errorf("the tags of the drafts are %v", tags)
+	}
+	if lines := strings.Count(undoLog.String(), "\n"); lines != 3 {
+		t.Errorf("the undo log has %v lines, want %v", lines, 3)
+	}
+
+	report, err = blog.UndoBulk(ctx, &undoLog, gotumblr.BulkOptions{})
+	if err != nil || report.Count(gotumblr.BulkSucceeded) != 3 {
+		t.Fatalf("UndoBulk returned %+v, %+v", report, err)
+	}
+	if tags := tagsOf(blog.Posts("", map[string]string{}).Posts); !reflect.DeepEqual(tags, [][]string{{"cats"}, {"wip", "colour"}}) {
+		t.Errorf("the tags of the posts after UndoBulk are %v", tags)
+	}
+	if tags := tagsOf(blog.Queue(map[string]string{}).Posts); !reflect.DeepEqual(tags, [][]string{{"Color", "cats"}}) {
+		t.Errorf("the tags of the queue after UndoBulk are %v", tags)
+	}
+}
+
+func TestExportBlog(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	client := s.Client()
+	blog := client.Blog("mgterzieva")
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	cat := s.AddMedia("cat.jpg", []byte("a cat"))
+	song := s.AddMedia("song.mp3", []byte("a song"))
+	blog.CreatePhoto(map[string]string{"source": cat, "tags": "cats"})
+	blog.CreateAudio(map[string]string{"external_url": song})
+	blog.CreatePhoto(map[string]string{"source": s.URL + "/media/missing.jpg"})
+	blog.CreateText(map[string]string{"body": "Soon", "state": "queue"})
+	blog.CreateText(map[string]string{"body": "Draft", "state": "draft"})
+	var liked gotumblr.BasePost
+	json.Unmarshal(blog.Posts("", map[string]string{"id": "1001"}).Posts[0], &liked)
+	client.Like("1001", liked.Reblog_key)
+
+	manifest, err := client.ExportBlog(ctx, "mgterzieva", dir, gotumblr.ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportBlog returned %+v, want %+v", err, nil)
+	}
+	wantPosts := map[string]int{"posts": 3, "queue": 1, "drafts": 1, "submissions": 0, "likes": 1}
+	if !reflect.DeepEqual(manifest.Posts, wantPosts) {
+		t.Errorf("the manifest counts %v posts, want %v", manifest.Posts, wantPosts)
+	}
+	wantMedia := map[string]string{cat: "media/1001_0.jpg", song: "media/1002_0.mp3"}
+	if !reflect.DeepEqual(manifest.Media, wantMedia) || len(manifest.FailedMedia) != 1 {
+		t.Errorf("the manifest has media %v and failed media %v", manifest.Media, manifest.FailedMedia)
+	}
+	for _, name := range []string{"blog.json", "avatar.png", "posts.jsonl", "media/1001_0.jpg"} {
+		if _, ok := manifest.Files[name]; !ok {
+			t.Errorf("the manifest has no checksum of %v", name)
+		}
+	}
+	if err := gotumblr.VerifyArchive(dir); err != nil {
+		t.Errorf("VerifyArchive returned %+v, want %+v", err, nil)
+	}
+	posts, err := gotumblr.ReadArchivePosts(dir, "posts")
+	if err != nil || len(posts) != 3 {
+		t.Fatalf("ReadArchivePosts returned %v posts, %+v", len(posts), err)
+	}
+	if post, _ := posts[1].Post(); post.(gotumblr.AudioPost).Audio_url != song {
+		t.Errorf("the archived audio post is %+v", post)
+	}
+
+	blog.CreateText(map[string]string{"body": "New"})
+	manifest, err = client.ExportBlog(ctx, "mgterzieva", dir, gotumblr.ExportOptions{})
+	if err != nil || manifest.Posts["posts"] != 4 {
+		t.Fatalf("the second ExportBlog returned %+v, %+v", manifest, err)
+	}
+	posts, _ = gotumblr.ReadArchivePosts(dir, "posts")
+	if post, _ := posts[0].Post(); post.(gotumblr.TextPost).Body != "New" {
+		t.Errorf("the newest archived post is %+v", post)
+	}
+	if requests := s.MediaRequests("cat.jpg"); requests != 1 {
+		t.Errorf("cat.jpg was requested %v times, want %v", requests, 1)
+	}
+	if _, err := client.ExportBlog(ctx, "thehungergames", dir, gotumblr.ExportOptions{}); err == nil {
+		t.Errorf("ExportBlog of another blog to the archive returned %+v, want an error", err)
+	}
+}
+
+func TestImportArchive(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	s.AddBlog("mgterzieva-art", true)
+	client := s.Client()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	source := client.Blog("mgterzieva")
+	source.CreateText(map[string]string{"body": "Hello", "tags": "hello,world", "slug": "hello-world", "date": "2014-02-14 18:30:00 GMT"})
+	source.CreatePhoto(map[string]string{"source": s.AddMedia("cat.jpg", []byte("a cat")), "caption": "Cat"})
+	source.CreateQuote(map[string]string{"quote": "A happy heart.", "source": "Proverbs", "state": "queue", "publish_on": "2030-10-31T12:00:00Z"})
+	source.CreateText(map[string]string{"body": "Soon", "state": "draft"})
+	if _, err := client.ExportBlog(ctx, "mgterzieva", dir, gotumblr.ExportOptions{}); err != nil {
+		t.Fatalf("ExportBlog returned %+v, want %+v", err, nil)
+	}
+
+	target := client.Blog("mgterzieva-art")
+	s.SetRateLimit(2)
+	ids, err := client.ImportArchive(ctx, "mgterzieva-art", dir, gotumblr.ImportOptions{})
+	if err == nil || len(ids) != 2 {
+		t.Fatalf("ImportArchive returned %v, %+v, want an error after two posts", ids, err)
+	}
+	s.SetRateLimit(0)
+	ids, err = client.ImportArchive(ctx, "mgterzieva-art", dir, gotumblr.ImportOptions{})
+	want := map[string]string{"1001": "1005", "1002": "1006", "1003": "1007", "1004": "1008"}
+	if err != nil || !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ImportArchive returned %v, %+v, want %v", ids, err, want)
+	}
+	ids, err = client.ImportArchive(ctx, "mgterzieva-art", dir, gotumblr.ImportOptions{})
+	if err != nil || !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Import of an imported archive returned %v, %+v, want %v", ids, err, want)
+	}
+	content, _ := ioutil.ReadFile(filepath.Join(dir, "import_mgterzieva-art.json"))
+	var mapped map[string]string
+	if json.Unmarshal(content, &mapped); !reflect.DeepEqual(mapped, want) {
+		t.Errorf("the map file is %s", content)
+	}
+
+	posts := target.Posts("", map[string]string{})
+	if posts.Total_posts != 2 {
+		t.Fatalf("Total_posts of the target returned %v, want %v", posts.Total_posts, 2)
+	}
+	var text gotumblr.TextPost
+	json.Unmarshal(posts.Posts[1], &text)
+	if text.Body != "Hello" || text.Slug != "hello-world" || text.Date != "2014-02-14 18:30:00 GMT" || !reflect.DeepEqual(text.Tags, []string{"hello", "world"}) {
+		t.Errorf("the imported text post is %+v", text)
+	}
+	var photo gotumblr.PhotoPost
+	json.Unmarshal(posts.Posts[0], &photo)
+	if photo.Caption != "Cat" || len(photo.Photos) != 1 || photo.Photos[0].Alt_sizes[0].Url != s.URL+"/media/upload_1006_0.jpg" {
+		t.Errorf("the imported photo post is %+v", photo)
+	}
+	queued := target.QueuedPosts(map[string]string{})
+	if len(queued) != 1 || queued[0].Id != 1007 || queued[0].PublishTime().UTC().Format(time.RFC3339) != "2030-10-31T12:00:00Z" {
+		t.Errorf("the imported queue is %+v", queued)
+	}
+	if drafts := target.Drafts(map[string]string{}); len(drafts.Posts) != 1 {
+		t.Errorf("Drafts of the target returned %v posts, want %v", len(drafts.Posts), 1)
+	}
+}
+
+func TestRenderArchiveSite(t *testing.T) {
+	s := newServer()
+	defer s.Close()
+	blog := s.Client().Blog("mgterzieva")
+	archive, site := t.TempDir(), t.TempDir()
+
+	cat := s.AddMedia("cat.jpg", []byte("a cat"))
+	blog.CreateText(map[string]string{"title": "Hello", "body": "<p>Hello, world!</p>", "slug": "hello-world", "tags": "Hello"})
+	blog.CreatePhoto(map[string]string{"source": cat, "tags": "cats,hello"})
+	blog.CreateChatPost(map[string]string{"conversation": "Alice: Hi!\nBob: Hello!"})
+	blog.CreateText(map[string]string{"body": "Secret", "state": "private"})
+	blog.CreatePhoto(map[string]string{"source": s.AddMedia("dog.jpg", []byte("a dog")), "state": "draft"})
+	if _, err := s.Client().ExportBlog(context.Background(), "mgterzieva", archive, gotumblr.ExportOptions{}); err != nil {
+		t.Fatalf("ExportBlog returned %+v, want %+v", err, nil)
+	}
+	if err := gotumblr.RenderArchiveSite(archive, site, gotumblr.SiteOptions{Title: "Maria's blog"}); err != nil {
+		t.Fatalf("RenderArchiveSite returned %+v, want %+v", err, nil)
+	}
+	read := func(page string) string {
+		content, err := ioutil.ReadFile(filepath.Join(site, filepath.FromSlash(page)))
+		if err != nil {
+			t.Errorf("%v wasn't rendered: %v", page, err)
+		}
+		return string(content)
+	}
+	index := read("index.html")
+	for _, want := range []string{"<title>Maria&#39;s blog</title>", `<img src="media/1002_0.jpg"`, "<dt>Bob:</dt><dd>Hello!</dd>", `href="post/1001/hello-world/index.html"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html doesn't contain %v:\n%v", want, index)
+		}
+	}
+	if strings.Contains(index, "Secret") {
+		t.Errorf("the private post was rendered:\n%v", index)
+	}
+	if page := read("post/1001/hello-world/index.html"); !strings.Contains(page, "<p>Hello, world!</p>") {
+		t.Errorf("the page of the text post is:\n%v", page)
+	}
+	if page := read("tagged/hello/index.html"); strings.Count(page, "<article") != 2 {
+		t.Errorf("tagged/hello/index.html is:\n%v", page)
+	}
+	if media := read("media/1002_0.jpg"); media != "a cat" {
+		t.Errorf("the photo was copied as %q", media)
+	}
+	if _, err := os.Stat(filepath.Join(site, "media", "1005_0.jpg")); !os.IsNotExist(err) {
+		t.Errorf("the photo of the draft was copied")
+	}
+}
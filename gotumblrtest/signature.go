@@ -0,0 +1,80 @@
+package gotumblrtest
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+//Checks the OAuth 1.0a HMAC-SHA1 signature of the request against the server's credentials.
+//The request form must already be parsed.
+func (s *Server) verify(r *http.Request) error {
+	oauth, err := parseAuthorization(r.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+	if oauth["oauth_consumer_key"] != s.ConsumerKey || oauth["oauth_token"] != s.Token {
+		return errors.New("gotumblrtest: unknown consumer key or token")
+	}
+	if oauth["oauth_signature_method"] != "HMAC-SHA1" {
+		return errors.New("gotumblrtest: unsupported signature method")
+	}
+	if apiKey := r.Form.Get("api_key"); apiKey != "" && apiKey != s.ConsumerKey {
+		return errors.New("gotumblrtest: unknown api_key")
+	}
+	pairs := []string{}
+	for key, values := range r.Form {
+		for _, value := range values {
+			pairs = append(pairs, escape(key)+"="+escape(value))
+		}
+	}
+	for key, value := range oauth {
+		if key != "oauth_signature" && key != "realm" {
+			pairs = append(pairs, escape(key)+"="+escape(value))
+		}
+	}
+	sort.Strings(pairs)
+	base := strings.Join([]string{
+		r.Method,
+		escape("http://" + r.Host + r.URL.Path),
+		escape(strings.Join(pairs, "&")),
+	}, "&")
+	mac := hmac.New(sha1.New, []byte(escape(s.ConsumerSecret)+"&"+escape(s.TokenSecret)))
+	mac.Write([]byte(base))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(oauth["oauth_signature"])) {
+		return errors.New("gotumblrtest: invalid signature")
+	}
+	return nil
+}
+
+//Parses the parameters of an OAuth Authorization header.
+func parseAuthorization(header string) (map[string]string, error) {
+	if !strings.HasPrefix(header, "OAuth ") {
+		return nil, errors.New("gotumblrtest: missing OAuth Authorization header")
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(header[len("OAuth "):], ",") {
+		param = strings.TrimSpace(param)
+		i := strings.Index(param, "=")
+		if i == -1 {
+			return nil, errors.New("gotumblrtest: malformed Authorization header")
+		}
+		value, err := url.QueryUnescape(strings.Trim(param[i+1:], `"`))
+		if err != nil {
+			return nil, err
+		}
+		params[param[:i]] = value
+	}
+	return params, nil
+}
+
+//Percent-encodes s as required by OAuth 1.0a.
+func escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestFakeIsUpToDate(t *testing.T) {
+	source, err := ioutil.ReadFile("../../client.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := generate(source)
+	if err != nil {
+		t.Fatalf("generate returned %+v, want %+v", err, nil)
+	}
+	fake, err := ioutil.ReadFile("../../fake_client_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, fake) {
+		t.Errorf("fake_client_gen.go is out of date, run go generate")
+	}
+}
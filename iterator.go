@@ -0,0 +1,151 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+)
+
+//The number of results iterators request at a time.
+const iteratorPageSize = 20
+
+//Walks a paginated list a page at a time; the iterators are built on it.
+//A pager without a fetch function has no more items than the ones in page.
+type pager[T any] struct {
+	//Requests the page that starts at offset. A page shorter than iteratorPageSize is the last one.
+	fetch  func(offset int) ([]T, error)
+	page   []T
+	item   T
+	offset int
+	done   bool
+	err    error
+}
+
+//Advances to the next item, requesting the next page when needed.
+func (p *pager[T]) next() bool {
+	for len(p.page) == 0 {
+		if p.done || p.err != nil || p.fetch == nil {
+			return false
+		}
+		p.page, p.err = p.fetch(p.offset)
+		p.offset += len(p.page)
+		p.done = len(p.page) < iteratorPageSize
+	}
+	p.item, p.page = p.page[0], p.page[1:]
+	return true
+}
+
+//Returns a pager over the given items, which requests nothing.
+func itemsPager[T any](items []T) pager[T] {
+	return pager[T]{page: items, done: true}
+}
+
+//Returns a fetch function that requests the pages of requestUrl by offset, with the given options,
+//and returns the items that decode picks from each response.
+func offsetPages[T, R any](ctx context.Context, trc *TumblrRestClient, requestUrl string, options map[string]string, decode func(*R) []T) func(offset int) ([]T, error) {
+	return func(offset int) ([]T, error) {
+		var result R
+		err := trc.page(ctx, requestUrl, options, offset, &result)
+		return decode(&result), err
+	}
+}
+
+//Iterates over a paginated list of users, such as the followers of a blog.
+//Call Next until it returns false and check Err afterwards:
+//
+//	it := client.FollowersIterator(ctx, "mgterzieva")
+//	for it.Next() {
+//		fmt.Println(it.User().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+//The zero value has no users.
+type UserIterator struct {
+	pager[User]
+}
+
+//Returns an iterator over the given users, e.g. for the FollowersIteratorFunc of a FakeClient.
+func NewUserIterator(users ...User) *UserIterator {
+	return &UserIterator{itemsPager(users)}
+}
+
+//Advances to the next user, requesting the next page when needed.
+//It returns false when there are no more users or a request failed.
+func (it *UserIterator) Next() bool {
+	return it != nil && it.next()
+}
+
+//Returns the current user.
+func (it *UserIterator) User() User {
+	return it.item
+}
+
+//Returns the error that stopped the iteration, if any.
+func (it *UserIterator) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
+
+//Iterates over a paginated list of blogs, such as the blogs a blog follows or blocks.
+//It is used like UserIterator. The zero value has no blogs.
+type BlogIterator struct {
+	pager[FollowedBlog]
+}
+
+//Returns an iterator over the given blogs, e.g. for the BlocksIteratorFunc of a FakeClient.
+func NewBlogIterator(blogs ...FollowedBlog) *BlogIterator {
+	return &BlogIterator{itemsPager(blogs)}
+}
+
+//Advances to the next blog, requesting the next page when needed.
+//It returns false when there are no more blogs or a request failed.
+func (it *BlogIterator) Next() bool {
+	return it != nil && it.next()
+}
+
+//Returns the current blog.
+func (it *BlogIterator) Blog() FollowedBlog {
+	return it.item
+}
+
+//Returns the error that stopped the iteration, if any.
+func (it *BlogIterator) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
+
+//Iterates over a paginated list of posts, such as the posts or the drafts of a blog.
+//It is used like UserIterator; unmarshal the posts into the type of post you need.
+//The zero value has no posts.
+type PostIterator struct {
+	pager[json.RawMessage]
+}
+
+//Returns an iterator over the given posts, e.g. for the PostsIteratorFunc of a FakeClient.
+func NewPostIterator(posts ...json.RawMessage) *PostIterator {
+	return &PostIterator{itemsPager(posts)}
+}
+
+//Advances to the next post, requesting the next page when needed.
+//It returns false when there are no more posts or a request failed.
+func (it *PostIterator) Next() bool {
+	return it != nil && it.next()
+}
+
+//Returns the current post.
+func (it *PostIterator) Post() json.RawMessage {
+	return it.item
+}
+
+//Returns the error that stopped the iteration, if any.
+func (it *PostIterator) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
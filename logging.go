@@ -0,0 +1,113 @@
+package gotumblr
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+//Sets the logger that records every request made through the TumblrRequest.
+//Requests are logged at debug level before they are sent, at info level when they succeed
+//and at error level when they fail. OAuth tokens, signatures, secrets and api_key values are always redacted.
+//logger: nil turns logging off, which is the default.
+func (tr *TumblrRequest) SetLogger(logger *slog.Logger) {
+	tr.logger = logger
+}
+
+//Sets the logger that records every request made through the TumblrRestClient.
+//See TumblrRequest.SetLogger.
+func (trc *TumblrRestClient) SetLogger(logger *slog.Logger) {
+	trc.request.SetLogger(logger)
+}
+
+//Logs that the call is about to be sent.
+func (tr *TumblrRequest) logSend(call *APICall) {
+	if tr.logger == nil || !tr.logger.Enabled(call.Context, slog.LevelDebug) {
+		return
+	}
+	tr.logger.LogAttrs(call.Context, slog.LevelDebug, "gotumblr: sending request",
+		slog.String("method", call.Method),
+		slog.String("endpoint", call.Endpoint),
+		slog.Int("attempt", call.Attempt),
+		slog.Any("params", tr.redactParams(call.Params)),
+	)
+}
+
+//Logs the outcome of the call.
+func (tr *TumblrRequest) logDone(call *APICall) {
+	if tr.logger == nil {
+		return
+	}
+	status := callStatus(call)
+	attrs := []slog.Attr{
+		slog.String("method", call.Method),
+		slog.String("endpoint", call.Endpoint),
+		slog.Int("status", status),
+		slog.Duration("duration", call.Duration),
+		slog.Int("attempt", call.Attempt),
+	}
+	for _, window := range []string{"Perhour", "Perday"} {
+		header := "X-Ratelimit-" + window + "-Remaining"
+		if remaining, err := strconv.ParseInt(call.ResponseHeader.Get(header), 10, 64); err == nil {
+			attrs = append(attrs, slog.Int64("ratelimit_"+strings.ToLower(window)+"_remaining", remaining))
+		}
+	}
+	level, msg := slog.LevelInfo, "gotumblr: request done"
+	if call.Err != nil {
+		level, msg = slog.LevelError, "gotumblr: request failed"
+		attrs = append(attrs, slog.String("error", tr.redact(call.Err.Error())))
+	} else if status >= 400 {
+		level, msg = slog.LevelError, "gotumblr: request failed"
+		attrs = append(attrs, slog.String("error", tr.redact(call.Response.Meta.Msg)))
+	}
+	tr.logger.LogAttrs(call.Context, level, msg, attrs...)
+}
+
+//Logs an error that is not tied to an API call.
+func (tr *TumblrRequest) logError(msg string, err error) {
+	if tr.logger == nil {
+		return
+	}
+	tr.logger.LogAttrs(context.Background(), slog.LevelError, msg, slog.String("error", tr.redact(err.Error())))
+}
+
+//Returns the parameters as a log group with the api_key and OAuth values redacted.
+func (tr *TumblrRequest) redactParams(params map[string]string) slog.Value {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		value := params[key]
+		if key == "api_key" || strings.HasPrefix(key, "oauth_") {
+			value = redacted
+		} else {
+			value = tr.redact(value)
+		}
+		attrs = append(attrs, slog.String(key, value))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+//Replaces the credentials of the TumblrRequest that appear in s.
+//Error messages can contain them, e.g. in the url of a failed request.
+func (tr *TumblrRequest) redact(s string) string {
+	secrets := []string{
+		tr.apiKey,
+		tr.service.ClientConfig.ConsumerSecret,
+		tr.userConfig.AccessTokenKey,
+		tr.userConfig.AccessTokenSecret,
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+	return s
+}
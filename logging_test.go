@@ -0,0 +1,101 @@
+package gotumblr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/posts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Perhour-Remaining", "99")
+		w.Write([]byte(`{"meta": {"status": 200, "msg": "OK"}, "response": {"total_posts": 8}}`))
+	})
+
+	host, _ := url.Parse(server.URL)
+	client = NewTumblrRestClient("consumer_key", "consumer_secret", "oauth_token", "oauth_secret", "", host.String())
+	var output bytes.Buffer
+	client.SetLogger(slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	client.Posts("mgterzieva", "", map[string]string{"tag": "golang"})
+
+	for _, secret := range []string{"consumer_key", "consumer_secret", "oauth_token", "oauth_secret"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("log contains %v: %v", secret, output.String())
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log has %v records, want 2: %v", len(lines), output.String())
+	}
+	var sent, done map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &sent)
+	json.Unmarshal([]byte(lines[1]), &done)
+	params := sent["params"].(map[string]interface{})
+	if sent["level"] != "DEBUG" || params["api_key"] != "REDACTED" || params["tag"] != "golang" {
+		t.Errorf("first record = %v, want a debug record with redacted params", sent)
+	}
+	if done["level"] != "INFO" || done["endpoint"] != "/v2/blog/mgterzieva/posts" || done["status"] != float64(200) {
+		t.Errorf("second record = %v, want an info record for the endpoint", done)
+	}
+	if done["ratelimit_perhour_remaining"] != float64(99) || done["attempt"] != float64(1) {
+		t.Errorf("second record = %v, want rate limit and attempt", done)
+	}
+}
+
+func TestSetLoggerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/follow", "POST", `{"meta": {"status": 404, "msg": "Not Found"}}`, map[string]string{}, t)
+
+	var output bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&output, nil)))
+	client.Follow("thehungergames")
+
+	if !strings.Contains(output.String(), "level=ERROR") || !strings.Contains(output.String(), `error="Not Found"`) {
+		t.Errorf("log = %v, want an error record", output.String())
+	}
+}
+
+type loggerContextKey struct{}
+
+//A slog.Handler that records the value of loggerContextKey in the context of every record.
+type contextRecorder struct {
+	slog.Handler
+	values []interface{}
+}
+
+func (h *contextRecorder) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+func (h *contextRecorder) Handle(ctx context.Context, record slog.Record) error {
+	h.values = append(h.values, ctx.Value(loggerContextKey{}))
+	return nil
+}
+
+func TestSetLoggerContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/info", "GET", `{"response": {"blog": {}}}`, map[string]string{}, t)
+
+	recorder := &contextRecorder{Handler: slog.NewTextHandler(ioutil.Discard, nil)}
+	client.SetLogger(slog.New(recorder))
+	ctx := context.WithValue(context.Background(), loggerContextKey{}, "request-42")
+	client.request.GetContext(ctx, "/v2/blog/mgterzieva/info", map[string]string{})
+
+	if !reflect.DeepEqual(recorder.values, []interface{}{"request-42", "request-42"}) {
+		t.Errorf("the records were logged with the context values %v, want the context of the call", recorder.values)
+	}
+}
@@ -0,0 +1,83 @@
+package gotumblr
+
+import (
+	"container/list"
+	"sync"
+)
+
+//LRUCache is an in-memory Cache that holds a limited number of entries
+//and evicts the least recently used one when it is full.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+//Creates an LRUCache that holds at most capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{capacity: capacity, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+//Returns the entry stored under the key and marks it as recently used.
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruItem).entry, true
+}
+
+//Stores the entry under the key, evicting the least recently used entry if the cache is full.
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruItem{key, entry})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+//Removes the entry stored under the key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+//Removes all entries about the given blog.
+func (c *LRUCache) DeleteBlog(blog string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, element := range c.entries {
+		if element.Value.(*lruItem).entry.Blog == blog {
+			c.order.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+//Returns the number of entries in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
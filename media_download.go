@@ -0,0 +1,331 @@
+package gotumblr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+//The number of files DownloadMedia downloads at the same time when DownloadOptions.Concurrency is not set.
+const DefaultDownloadConcurrency = 4
+
+//Returns the size of the photo whose width is nearest to the given one, preferring the larger
+//of two sizes that are as near. It returns the largest size if width is not positive.
+func (p PhotoObject) Size(width int64) AltSize {
+	var best AltSize
+	for _, size := range p.Alt_sizes {
+		switch {
+		case best.Url == "":
+			best = size
+		case width <= 0:
+			if size.Width > best.Width {
+				best = size
+			}
+		default:
+			distance, bestDistance := abs(size.Width-width), abs(best.Width-width)
+			if distance < bestDistance || distance == bestDistance && size.Width > best.Width {
+				best = size
+			}
+		}
+	}
+	return best
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+//Options of DownloadMedia.
+type DownloadOptions struct {
+	//The width of the photos to download; the size nearest to it is picked. The largest size if zero.
+	PhotoWidth int64
+	//The number of files downloaded at the same time. DefaultDownloadConcurrency if zero.
+	Concurrency int
+	//Called after each file is downloaded or fails, one call at a time.
+	Progress func(DownloadEvent)
+	//Media that was downloaded before, by url: the path of its file, relative to the directory.
+	//It isn't downloaded again while the file exists and new files with the same content are replaced by it.
+	Downloaded map[string]string
+}
+
+//A media file of a post.
+type MediaFile struct {
+	//The post and the position of the file among its media.
+	PostID int64  `json:"post_id"`
+	Index  int    `json:"index"`
+	Url    string `json:"url"`
+	//The path of the file relative to the directory, with forward slashes. Empty if the download failed.
+	Path   string `json:"path,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+	//The content of the file was downloaded for other media already; Path is the file of that media.
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+//Reports the progress of DownloadMedia.
+type DownloadEvent struct {
+	//The file that was just handled.
+	File MediaFile
+	//The number of files handled so far and the number of files to download.
+	Done  int
+	Total int
+}
+
+//Downloads the media of the posts to the directory dir, which is created if needed: the photos of photo posts,
+//the audio file and album art of audio posts and the video file and thumbnail of video posts.
+//The file of the index-th media of a post is named <post id>_<index><extension>.
+//Files are downloaded by a pool of workers. Partial files are kept as <name>.part when a download fails
+//and resumed with a Range request by the next call. Files with the same content as a file downloaded
+//before them are removed and their media points to that file.
+//It returns a MediaFile per media, in the order of the posts; failures of single files are recorded in them
+//and the returned error is about the download being canceled.
+func (trc *TumblrRestClient) DownloadMedia(ctx context.Context, dir string, posts []Post, options DownloadOptions) ([]MediaFile, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	files := []MediaFile{}
+	pending := []int{}
+	first := map[string]int{}
+	for _, post := range posts {
+		id := post.Base().Id
+		for i, mediaUrl := range postMedia(post, options.PhotoWidth) {
+			file := MediaFile{PostID: id, Index: i, Url: mediaUrl}
+			if known, ok := options.Downloaded[mediaUrl]; ok && fileExists(filepath.Join(dir, filepath.FromSlash(known))) {
+				file.Path = known
+			} else if _, ok := first[mediaUrl]; !ok {
+				first[mediaUrl] = len(files)
+				pending = append(pending, len(files))
+			}
+			files = append(files, file)
+		}
+	}
+
+	var mu sync.Mutex
+	handled := 0
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDownloadConcurrency
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				file := files[i]
+				name := mediaName(file.PostID, file.Index, file.Url)
+				path := filepath.Join(dir, name)
+				var err error
+				if !fileExists(path) {
+					err = trc.download(ctx, file.Url, path)
+				}
+				if err == nil {
+					file.Path = name
+					file.Size, file.Sha256, err = hashFile(path)
+				}
+				if ctx.Err() != nil {
+					continue
+				}
+				if err != nil {
+					file.Error = err.Error()
+				}
+				mu.Lock()
+				files[i] = file
+				handled++
+				if options.Progress != nil {
+					options.Progress(DownloadEvent{File: file, Done: handled, Total: len(pending)})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, i := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return files, ctx.Err()
+	}
+	return files, dedupMedia(dir, files, first)
+}
+
+//Removes the downloaded files whose content is the one of an earlier file, known or downloaded,
+//and points their media, and the media that share their url, to the file that is kept.
+//first maps the urls that were downloaded to the index of the file they were downloaded for.
+func dedupMedia(dir string, files []MediaFile, first map[string]int) error {
+	kept := map[string]string{}
+	for i := range files {
+		file := &files[i]
+		j, downloaded := first[file.Url]
+		if downloaded && j != i {
+			original := files[j]
+			file.Path, file.Size, file.Sha256, file.Error = original.Path, original.Size, original.Sha256, original.Error
+			file.Duplicate = original.Path != ""
+			continue
+		}
+		if file.Path == "" {
+			continue
+		}
+		if file.Sha256 == "" {
+			size, sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+			if err != nil {
+				return err
+			}
+			file.Size, file.Sha256 = size, sum
+		}
+		original, ok := kept[file.Sha256]
+		if !ok || original == file.Path {
+			kept[file.Sha256] = file.Path
+			continue
+		}
+		if downloaded {
+			if err := os.Remove(filepath.Join(dir, filepath.FromSlash(file.Path))); err != nil {
+				return err
+			}
+		}
+		file.Path = original
+		file.Duplicate = true
+	}
+	return nil
+}
+
+//Returns the urls of the media of a post: the size of its photos nearest to photoWidth
+//(see PhotoObject.Size), its audio file and album art, or its video file and thumbnail.
+//The video file of a post without Video_url is looked for in the embed code of its largest player.
+func postMedia(post Post, photoWidth int64) []string {
+	urls := []string{}
+	switch post := post.(type) {
+	case PhotoPost:
+		for _, photo := range post.Photos {
+			urls = append(urls, photo.Size(photoWidth).Url)
+		}
+	case AudioPost:
+		urls = append(urls, post.Audio_url, post.Album_art)
+	case VideoPost:
+		videoUrl := post.Video_url
+		if videoUrl == "" {
+			var largest PlayerInfo
+			for _, player := range post.Player {
+				if player.Width >= largest.Width {
+					largest = player
+				}
+			}
+			videoUrl = videoSource(largest.Embed_code)
+		}
+		urls = append(urls, videoUrl, post.Thumbnail_url)
+	}
+	media := []string{}
+	for _, mediaUrl := range urls {
+		if mediaUrl != "" {
+			media = append(media, mediaUrl)
+		}
+	}
+	return media
+}
+
+var videoSourcePattern = regexp.MustCompile(`<source[^>]*\ssrc="([^"]+)"`)
+
+//Returns the url of the video file of a Tumblr player's embed code, which has it in a <source> element,
+//or an empty string for other players, such as the iframes of YouTube.
+func videoSource(embedCode string) string {
+	match := videoSourcePattern.FindStringSubmatch(embedCode)
+	if match == nil {
+		return ""
+	}
+	return html.UnescapeString(match[1])
+}
+
+//Returns the file name of the index-th media of the post with the given id.
+func mediaName(id int64, index int, mediaUrl string) string {
+	extension := ""
+	if parsed, err := url.Parse(mediaUrl); err == nil {
+		extension = strings.ToLower(path.Ext(parsed.Path))
+	}
+	return fmt.Sprintf("%d_%d%s", id, index, extension)
+}
+
+//Downloads the file at the url to the given path through the middleware chain. The file is written to <path>.part first,
+//which is resumed with a Range request if it exists and renamed to path when the download is complete.
+func (trc *TumblrRestClient) download(ctx context.Context, fileUrl, path string) error {
+	partPath := path + ".part"
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	httpResponse, err := trc.request.download(ctx, fileUrl, header)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case offset > 0 && httpResponse.StatusCode == http.StatusPartialContent &&
+		strings.HasPrefix(httpResponse.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+		flags = os.O_WRONLY | os.O_APPEND
+	case offset > 0 && (httpResponse.StatusCode == http.StatusPartialContent || httpResponse.StatusCode == http.StatusRequestedRangeNotSatisfiable):
+		//The partial file doesn't fit the file on the server, so it is downloaded anew.
+		httpResponse.Body.Close()
+		if err := os.Remove(partPath); err != nil {
+			return err
+		}
+		return trc.download(ctx, fileUrl, path)
+	case httpResponse.StatusCode != http.StatusOK:
+		return fmt.Errorf("gotumblr: downloading %s: %s", fileUrl, httpResponse.Status)
+	}
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, httpResponse.Body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partPath, path)
+}
+
+//Returns the size and the hex encoded SHA-256 checksum of the file at path.
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
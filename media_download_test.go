@@ -0,0 +1,146 @@
+package gotumblr
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPhotoSize(t *testing.T) {
+	photo := PhotoObject{Alt_sizes: []AltSize{{Width: 500, Url: "500"}, {Width: 1280, Url: "1280"}, {Width: 250, Url: "250"}, {Width: 75, Url: "75"}}}
+	tests := []struct {
+		width int64
+		want  string
+	}{{0, "1280"}, {400, "500"}, {375, "500"}, {100, "75"}, {2000, "1280"}}
+	for _, test := range tests {
+		if size := photo.Size(test.width); size.Url != test.want {
+			t.Errorf("Size(%v) returned %+v, want the size with url %v", test.width, size, test.want)
+		}
+	}
+	if size := (PhotoObject{}).Size(500); size != (AltSize{}) {
+		t.Errorf("Size of a photo without sizes returned %+v", size)
+	}
+}
+
+func TestPostMedia(t *testing.T) {
+	photo := PhotoPost{Photos: []PhotoObject{
+		{Alt_sizes: []AltSize{{Width: 500, Url: "http://media.tumblr.com/cat_500.jpg"}, {Width: 1280, Url: "http://media.tumblr.com/cat_1280.jpg"}}},
+		{Alt_sizes: []AltSize{{Width: 75, Url: "http://media.tumblr.com/dog_75.png"}}},
+	}}
+	tumblrPlayer := `<video width="500"><source src="https://va.media.tumblr.com/tumblr_cat.mp4?a=1&amp;b=2" type="video/mp4"></video>`
+	tests := []struct {
+		post  Post
+		width int64
+		want  []string
+	}{
+		{photo, 0, []string{"http://media.tumblr.com/cat_1280.jpg", "http://media.tumblr.com/dog_75.png"}},
+		{photo, 500, []string{"http://media.tumblr.com/cat_500.jpg", "http://media.tumblr.com/dog_75.png"}},
+		{AudioPost{Audio_url: "http://a.tumblr.com/song.mp3", Album_art: "http://media.tumblr.com/art.jpg"}, 0, []string{"http://a.tumblr.com/song.mp3", "http://media.tumblr.com/art.jpg"}},
+		{VideoPost{Video_url: "http://v.tumblr.com/cat.mp4", Thumbnail_url: "http://media.tumblr.com/cat.jpg"}, 0, []string{"http://v.tumblr.com/cat.mp4", "http://media.tumblr.com/cat.jpg"}},
+		{VideoPost{Player: []PlayerInfo{{Width: 250, Embed_code: "<small>"}, {Width: 500, Embed_code: tumblrPlayer}}}, 0, []string{"https://va.media.tumblr.com/tumblr_cat.mp4?a=1&b=2"}},
+		{VideoPost{Player: []PlayerInfo{{Width: 500, Embed_code: `<iframe src="https://www.youtube.com/embed/cat"></iframe>`}}}, 0, []string{}},
+		{TextPost{}, 0, []string{}},
+	}
+	for _, test := range tests {
+		if media := postMedia(test.post, test.width); !reflect.DeepEqual(media, test.want) {
+			t.Errorf("postMedia(%+v, %v) returned %v, want %v", test.post, test.width, media, test.want)
+		}
+	}
+}
+
+func TestMediaName(t *testing.T) {
+	if got := mediaName(72078164824, 1, "http://media.tumblr.com/cat_1280.JPG?v=2"); got != "72078164824_1.jpg" {
+		t.Errorf("mediaName returned %v", got)
+	}
+	if got := mediaName(7, 0, "http://v.tumblr.com/tumblr_abc"); got != "7_0" {
+		t.Errorf("mediaName returned %v", got)
+	}
+}
+
+func TestDownloadMedia(t *testing.T) {
+	setup()
+	defer teardown()
+
+	content := map[string][]byte{
+		"/cat.jpg":  []byte("a cat"),
+		"/same.jpg": []byte("a cat"),
+		"/song.mp3": []byte("a long song"),
+	}
+	var mu sync.Mutex
+	ranges := map[string]string{}
+	for name, body := range content {
+		body := body
+		mux.HandleFunc(name, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			ranges[r.URL.Path] = r.Header.Get("Range")
+			mu.Unlock()
+			http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(body))
+		})
+	}
+	cat, same, song, missing := server.URL+"/cat.jpg", server.URL+"/same.jpg", server.URL+"/song.mp3", server.URL+"/missing.png"
+	posts := []Post{
+		PhotoPost{BasePost: BasePost{Id: 1}, Photos: []PhotoObject{{Alt_sizes: []AltSize{{Width: 75, Url: missing}, {Width: 500, Url: cat}}}}},
+		AudioPost{BasePost: BasePost{Id: 2}, Audio_url: song, Album_art: same},
+		PhotoPost{BasePost: BasePost{Id: 3}, Photos: []PhotoObject{{Alt_sizes: []AltSize{{Width: 500, Url: cat}}}}},
+	}
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "2_0.mp3.part"), []byte("a lo"), 0644)
+	var downloads int32
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			if call.Name == "download" {
+				atomic.AddInt32(&downloads, 1)
+			}
+			return next(call)
+		}
+	})
+
+	events := 0
+	files, err := client.DownloadMedia(context.Background(), dir, posts, DownloadOptions{Progress: func(event DownloadEvent) {
+		events++
+		if event.Total != 3 {
+			t.Errorf("the event %+v has a total of %v files, want %v", event, event.Total, 3)
+		}
+	}})
+	if err != nil {
+		t.Fatalf("DownloadMedia returned %+v, want %+v", err, nil)
+	}
+	catSum := "51e467415607798220a3776f6ae1a2a09ddc7e5dcdc955d685477b4cf05ade22"
+	want := []MediaFile{
+		{PostID: 1, Index: 0, Url: cat, Path: "1_0.jpg", Size: 5, Sha256: catSum},
+		{PostID: 2, Index: 0, Url: song, Path: "2_0.mp3", Size: 11, Sha256: files[1].Sha256},
+		{PostID: 2, Index: 1, Url: same, Path: "1_0.jpg", Size: 5, Sha256: catSum, Duplicate: true},
+		{PostID: 3, Index: 0, Url: cat, Path: "1_0.jpg", Size: 5, Sha256: catSum, Duplicate: true},
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("DownloadMedia returned %+v, want %+v", files, want)
+	}
+	if events != 3 {
+		t.Errorf("Progress was called %v times, want %v", events, 3)
+	}
+	if downloads != 3 {
+		t.Errorf("the middleware saw %v downloads, want %v", downloads, 3)
+	}
+	if ranges["/song.mp3"] != "bytes=4-" {
+		t.Errorf("the partial song was requested with range %q, want %q", ranges["/song.mp3"], "bytes=4-")
+	}
+	if song, _ := ioutil.ReadFile(filepath.Join(dir, "2_0.mp3")); string(song) != "a long song" {
+		t.Errorf("the song was downloaded as %q", song)
+	}
+	if fileExists(filepath.Join(dir, "2_1.jpg")) || fileExists(filepath.Join(dir, "2_0.mp3.part")) {
+		t.Errorf("the duplicate or the partial file were kept")
+	}
+
+	posts = append(posts, PhotoPost{BasePost: BasePost{Id: 4}, Photos: []PhotoObject{{Alt_sizes: []AltSize{{Width: 75, Url: missing}}}}})
+	files, err = client.DownloadMedia(context.Background(), dir, posts[3:], DownloadOptions{})
+	if err != nil || len(files) != 1 || files[0].Path != "" || files[0].Error == "" {
+		t.Errorf("DownloadMedia of missing media returned %+v, %+v", files, err)
+	}
+}
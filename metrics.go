@@ -0,0 +1,41 @@
+package gotumblr
+
+import (
+	"strconv"
+	"time"
+)
+
+//Metrics receives measurements of the API calls made through a TumblrRequest.
+//ExpvarMetrics implements it with the standard library;
+//implement it yourself to feed another metrics system.
+type Metrics interface {
+	//Records how long a call to the endpoint took.
+	//endpoint: the name of the endpoint (e.g. /v2/blog/{blog}/info).
+	//status: the status of the response, 0 when no response was received.
+	ObserveLatency(method, endpoint string, status int, duration time.Duration)
+	//Counts a call to the endpoint that failed.
+	CountError(method, endpoint string, status int)
+	//Records the number of calls remaining in a rate limit window (hour or day).
+	SetQuotaRemaining(window string, remaining int64)
+}
+
+//Returns middleware that reports every API call to metrics.
+func MetricsMiddleware(metrics Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			start := time.Now()
+			response := next(call)
+			status := callStatus(call)
+			metrics.ObserveLatency(call.Method, call.Name, status, time.Since(start))
+			if call.Err != nil || status >= 400 {
+				metrics.CountError(call.Method, call.Name, status)
+			}
+			for window, header := range map[string]string{"hour": "X-Ratelimit-Perhour-Remaining", "day": "X-Ratelimit-Perday-Remaining"} {
+				if remaining, err := strconv.ParseInt(call.ResponseHeader.Get(header), 10, 64); err == nil {
+					metrics.SetQuotaRemaining(window, remaining)
+				}
+			}
+			return response
+		}
+	}
+}
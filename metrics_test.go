@@ -0,0 +1,85 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Perday-Remaining", "4999")
+		w.Write([]byte(`{"meta": {"status": 200, "msg": "OK"}, "response": {"blog": {}}}`))
+	})
+	handleFunc("/v2/user/follow", "POST", `{"meta": {"status": 404, "msg": "Not Found"}}`, map[string]string{}, t)
+
+	metrics := NewExpvarMetrics("", 1, 10)
+	client.Use(MetricsMiddleware(metrics))
+	client.BlogInfo("mgterzieva")
+	client.BlogInfo("mgterzieva.tumblr.com")
+	client.Follow("thehungergames")
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, nil)
+	exposition := recorder.Body.String()
+	for _, line := range []string{
+		`gotumblr_request_duration_seconds_bucket{method="GET",endpoint="/v2/blog/{blog}/info",le="10"} 2`,
+		`gotumblr_request_duration_seconds_count{method="GET",endpoint="/v2/blog/{blog}/info"} 2`,
+		`gotumblr_request_duration_seconds_count{method="POST",endpoint="/v2/user/follow"} 1`,
+		`gotumblr_request_errors_total{method="POST",endpoint="/v2/user/follow",status="404"} 1`,
+		`gotumblr_ratelimit_remaining{window="day"} 4999`,
+	} {
+		if !strings.Contains(exposition, line+"\n") {
+			t.Errorf("exposition doesn't contain %v:\n%v", line, exposition)
+		}
+	}
+}
+
+func TestExpvarMetricsBuckets(t *testing.T) {
+	metrics := NewExpvarMetrics("", 0.1, 1)
+	metrics.ObserveLatency("GET", "/v2/user/info", 200, 50*time.Millisecond)
+	metrics.ObserveLatency("GET", "/v2/user/info", 200, 500*time.Millisecond)
+	metrics.ObserveLatency("GET", "/v2/user/info", 200, 5*time.Second)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, nil)
+	for _, line := range []string{
+		`gotumblr_request_duration_seconds_bucket{method="GET",endpoint="/v2/user/info",le="0.1"} 1`,
+		`gotumblr_request_duration_seconds_bucket{method="GET",endpoint="/v2/user/info",le="1"} 2`,
+		`gotumblr_request_duration_seconds_bucket{method="GET",endpoint="/v2/user/info",le="+Inf"} 3`,
+		`gotumblr_request_duration_seconds_sum{method="GET",endpoint="/v2/user/info"} 5.55`,
+	} {
+		if !strings.Contains(recorder.Body.String(), line+"\n") {
+			t.Errorf("exposition doesn't contain %v:\n%v", line, recorder.Body.String())
+		}
+	}
+}
+
+func TestNewExpvarMetricsPublished(t *testing.T) {
+	metrics := NewExpvarMetrics("gotumblr_test_metrics", 1, 10)
+	metrics.SetQuotaRemaining("day", 4999)
+	var published struct {
+		Quota map[string]int64
+	}
+	json.Unmarshal([]byte(expvar.Get("gotumblr_test_metrics").String()), &published)
+	if published.Quota["day"] != 4999 {
+		t.Errorf("expvar quota = %v, want %v", published.Quota, 4999)
+	}
+	if again := NewExpvarMetrics("gotumblr_test_metrics"); again != metrics {
+		t.Errorf("NewExpvarMetrics with a published name returned new metrics, want the published ones")
+	}
+
+	if expvar.Get("gotumblr_test_counter") == nil {
+		expvar.NewInt("gotumblr_test_counter")
+	}
+	if other := NewExpvarMetrics("gotumblr_test_counter"); other == nil || expvar.Get("gotumblr_test_counter") == other {
+		t.Errorf("NewExpvarMetrics with the name of another variable returned %v", other)
+	}
+}
@@ -0,0 +1,198 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//An API call made through a TumblrRequest.
+//Middleware can inspect the call and change it before passing it on.
+type APICall struct {
+	//The context of the call; the request is canceled when it is done.
+	Context context.Context
+	//The HTTP method of the call (GET, POST or DELETE).
+	Method string
+	//The url path the call is made to (e.g. /v2/blog/mgterzieva/info).
+	Endpoint string
+	//The name of the endpoint, with the blog identifier left out (e.g. /v2/blog/{blog}/info).
+	//Downloads of files, such as media and avatars, are named "download" and have their url as Endpoint.
+	Name string
+	//The identifier of the blog the call is about, if any.
+	Blog string
+	//The parameters sent with the call.
+	//The parameters of multipart calls, such as uploads, are sent as fields of the body, before the files.
+	Params map[string]string
+	//Additional headers sent with the call.
+	Header http.Header
+	//A body sent instead of the form-encoded parameters, which then go in the query string.
+	//ContentType is its media type (e.g. application/json).
+	Body        []byte
+	ContentType string
+	//The number of the attempt to make the call, starting at 1.
+	//Middleware that retries calls should increase it before every new attempt.
+	Attempt int
+
+	//The following fields are set once the call has been sent.
+
+	//The response of the API.
+	Response CompleteResponse
+	//The HTTP status code and headers of the response; zero when no response was received.
+	StatusCode     int
+	ResponseHeader http.Header
+	//Whether the response was served from the cache without asking the API.
+	Cached bool
+	//Whether the response was shared with an identical call that was in flight.
+	Coalesced bool
+	//The error that prevented the call from getting a response, if any.
+	Err error
+	//When the call started and how long it took.
+	Start    time.Time
+	Duration time.Duration
+
+	//Whether to return redirect responses instead of following them.
+	noRedirect bool
+	//Whether the call downloads the file at Endpoint. The response is kept in httpResponse
+	//and its body is left open for the caller if the status is 200 or 206.
+	download     bool
+	httpResponse *http.Response
+	//The files of a multipart call, which is sent with a body built from Params and the files when they aren't nil.
+	files []UploadFile
+}
+
+//Handles an API call and returns the response.
+type Handler func(call *APICall) CompleteResponse
+
+//Wraps a Handler with additional behavior, such as logging, metrics or request rewriting.
+//A middleware normally calls next and may change the call before and the response after it.
+//It can also return a response without calling next.
+type Middleware func(next Handler) Handler
+
+//Adds middleware around every API call made through the TumblrRequest.
+//Middleware added first is the outermost, i.e. it sees the call first and the response last.
+//Use must not be called concurrently with requests.
+func (tr *TumblrRequest) Use(middleware ...Middleware) {
+	tr.middleware = append(tr.middleware, middleware...)
+}
+
+//Adds middleware around every API call made through the TumblrRestClient.
+//See TumblrRequest.Use.
+func (trc *TumblrRestClient) Use(middleware ...Middleware) {
+	trc.request.Use(middleware...)
+}
+
+//Makes an API call through the middleware chain.
+func (tr *TumblrRequest) call(ctx context.Context, method, requestUrl string, params map[string]string) CompleteResponse {
+	return tr.run(tr.newCall(ctx, method, requestUrl, params))
+}
+
+//Describes an API call.
+func (tr *TumblrRequest) newCall(ctx context.Context, method, requestUrl string, params map[string]string) *APICall {
+	name, blog := endpointName(requestUrl)
+	return &APICall{
+		Context:  ctx,
+		Method:   method,
+		Endpoint: requestUrl,
+		Name:     name,
+		Blog:     blog,
+		Params:   params,
+		Header:   http.Header{},
+		Attempt:  1,
+	}
+}
+
+//Passes the call through the middleware chain and returns its response.
+//Calls to a blog endpoint without a blog identifier fail without being sent.
+func (tr *TumblrRequest) run(call *APICall) CompleteResponse {
+	if call.Blog == "" && strings.HasPrefix(call.Endpoint, "/v2/blog/") {
+		call.Response = tr.fail(call, errors.New("gotumblr: no blog identifier"))
+		return call.Response
+	}
+	handler := Handler(tr.do)
+	for i := len(tr.middleware) - 1; i >= 0; i-- {
+		handler = tr.middleware[i](handler)
+	}
+	call.Response = handler(call)
+	return call.Response
+}
+
+//Handles the call once it has passed through all middleware.
+//Downloads are neither cached nor coalesced, since their body is read by the caller.
+func (tr *TumblrRequest) do(call *APICall) CompleteResponse {
+	if call.download {
+		return tr.roundTrip(call)
+	}
+	if tr.cache != nil {
+		return tr.cache.do(call, tr.coalesce)
+	}
+	return tr.coalesce(call)
+}
+
+//Sends the call, sharing the round trip with identical calls in flight if coalescing is on.
+func (tr *TumblrRequest) coalesce(call *APICall) CompleteResponse {
+	if tr.coalescing != nil {
+		return tr.coalescing.do(call, tr.roundTrip)
+	}
+	return tr.roundTrip(call)
+}
+
+//Sends the call to the API, timing and logging it.
+func (tr *TumblrRequest) roundTrip(call *APICall) CompleteResponse {
+	tr.logSend(call)
+	call.Start = time.Now()
+	call.Response = tr.send(call)
+	call.Duration = time.Since(call.Start)
+	tr.logDone(call)
+	return call.Response
+}
+
+//Returns the name of the endpoint with the given url path and the blog identifier in it, if any.
+func endpointName(requestUrl string) (name, blog string) {
+	if strings.HasPrefix(requestUrl, "/v2/user/filtered_tags/") {
+		return "/v2/user/filtered_tags/{tag}", ""
+	}
+	if !strings.HasPrefix(requestUrl, "/v2/blog/") {
+		return requestUrl, ""
+	}
+	rest := strings.TrimPrefix(requestUrl, "/v2/blog/")
+	if i := strings.Index(rest, "/"); i != -1 {
+		blog, rest = rest[:i], rest[i:]
+	} else {
+		blog, rest = rest, ""
+	}
+	if strings.HasPrefix(rest, "/avatar/") {
+		rest = "/avatar/{size}"
+	}
+	if strings.HasPrefix(rest, "/posts/") {
+		segments := strings.Split(rest, "/")
+		if len(segments) > 3 && isId(segments[2]) {
+			segments[2] = "{id}"
+			rest = strings.Join(segments, "/")
+		}
+	}
+	return "/v2/blog/{blog}" + rest, blog
+}
+
+//Reports whether s is a post id.
+func isId(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+//Returns the status of the call's response: the meta status if there is one,
+//otherwise the HTTP status code, or 0 when no response was received.
+func callStatus(call *APICall) int {
+	if call.Response.Meta.Status != 0 {
+		return int(call.Response.Meta.Status)
+	}
+	return call.StatusCode
+}
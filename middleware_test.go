@@ -0,0 +1,131 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUseObservesCalls(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/info", "GET", `{"meta": {"status": 200, "msg": "OK"}, "response": {"blog": {"name": "mgterzieva"}}}`, map[string]string{}, t)
+
+	var observed *APICall
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			response := next(call)
+			observed = call
+			return response
+		}
+	})
+	client.BlogInfo("mgterzieva.tumblr.com")
+
+	if observed == nil {
+		t.Fatalf("middleware was not called")
+	}
+	if observed.Method != "GET" || observed.Endpoint != "/v2/blog/mgterzieva/info" {
+		t.Errorf("call = %v %v, want GET /v2/blog/mgterzieva/info", observed.Method, observed.Endpoint)
+	}
+	if observed.Name != "/v2/blog/{blog}/info" || observed.Blog != "mgterzieva" {
+		t.Errorf("call name = %v, blog = %v, want /v2/blog/{blog}/info, mgterzieva", observed.Name, observed.Blog)
+	}
+	expected_meta := MetaInfo{Status: 200, Msg: "OK"}
+	if observed.Response.Meta != expected_meta || observed.StatusCode != 200 {
+		t.Errorf("call response = %+v (%v), want %+v", observed.Response.Meta, observed.StatusCode, expected_meta)
+	}
+	if observed.Start.IsZero() || observed.Duration <= 0 {
+		t.Errorf("call timing = %v, %v, want it set", observed.Start, observed.Duration)
+	}
+}
+
+func TestUseRewritesCalls(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/user/likes", func(w http.ResponseWriter, r *http.Request) {
+		checkParameters(r, map[string]string{"limit": "5"}, t)
+		if r.Header.Get("X-Request-Id") != "42" {
+			t.Errorf("X-Request-Id = %v, want 42", r.Header.Get("X-Request-Id"))
+		}
+		w.Write([]byte(`{"response": {"liked_count": 63}}`))
+	})
+
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			call.Header.Set("X-Request-Id", "42")
+			return next(call)
+		}
+	}, func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			call.Params["limit"] = "5"
+			return next(call)
+		}
+	})
+
+	likes := client.Likes(map[string]string{}).Liked_count
+	if likes != 63 {
+		t.Errorf("Likes returned %+v, want %v", likes, 63)
+	}
+}
+
+func TestUseOrder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	order := []string{}
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(call *APICall) CompleteResponse {
+				order = append(order, name)
+				response := next(call)
+				order = append(order, name)
+				return response
+			}
+		}
+	}
+	client.Use(trace("outer"), trace("inner"))
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			return CompleteResponse{Meta: MetaInfo{Status: 200}, Response: json.RawMessage(`{"user": {"name": "cached"}}`)}
+		}
+	})
+
+	info := client.Info().User
+	want := []string{"outer", "inner", "inner", "outer"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("middleware order = %v, want %v", order, want)
+	}
+	if info.Name != "cached" {
+		t.Errorf("Info returned %+v, want the response of the middleware", info)
+	}
+}
+
+func TestGetConnectionError(t *testing.T) {
+	setup()
+	teardown()
+
+	data := client.request.Get("/v2/user/info", map[string]string{})
+	if data.Meta.Msg == "" {
+		t.Errorf("Get returned %+v, want the connection error", data.Meta)
+	}
+}
+
+func TestEndpointName(t *testing.T) {
+	tests := []struct {
+		url, name, blog string
+	}{
+		{"/v2/user/info", "/v2/user/info", ""},
+		{"/v2/user/filtered_tags/spoilers", "/v2/user/filtered_tags/{tag}", ""},
+		{"/v2/blog/mgterzieva/posts/text", "/v2/blog/{blog}/posts/text", "mgterzieva"},
+		{"/v2/blog/mgterzieva/avatar/64", "/v2/blog/{blog}/avatar/{size}", "mgterzieva"},
+		{"/v2/blog/mgterzieva/posts/72078164824/mute", "/v2/blog/{blog}/posts/{id}/mute", "mgterzieva"},
+	}
+	for _, test := range tests {
+		if name, blog := endpointName(test.url); name != test.name || blog != test.blog {
+			t.Errorf("endpointName(%v) = %v, %v, want %v, %v", test.url, name, blog, test.name, test.blog)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package gotumblr
+
+import "time"
+
+//The kind of a notification.
+type NotificationType string
+
+const (
+	NotificationLike               NotificationType = "like"
+	NotificationReply              NotificationType = "reply"
+	NotificationFollow             NotificationType = "follow"
+	NotificationMentionInReply     NotificationType = "mention_in_reply"
+	NotificationMentionInPost      NotificationType = "mention_in_post"
+	NotificationReblogNaked        NotificationType = "reblog_naked"
+	NotificationReblogWithContent  NotificationType = "reblog_with_content"
+	NotificationAsk                NotificationType = "ask"
+	NotificationAnsweredAsk        NotificationType = "answered_ask"
+	NotificationNewGroupBlogMember NotificationType = "new_group_blog_member"
+	NotificationPostAttribution    NotificationType = "post_attribution"
+	NotificationConversationalNote NotificationType = "conversational_note"
+)
+
+//An activity on a blog, such as a like, a reblog or a new follower.
+type Notification struct {
+	Type      NotificationType
+	Timestamp int64
+	Unread    bool
+	//The post the notification is about, if any.
+	Target_post_id      string
+	Target_post_type    string
+	Target_post_summary string
+	//The blog the notification was sent to.
+	Target_tumblelog_name string
+	Target_tumblelog_uuid string
+	//The blog that caused the notification.
+	From_tumblelog_name string
+	From_tumblelog_uuid string
+	//Whether the target blog follows the blog that caused the notification.
+	Followed bool
+	//The post created by the activity (e.g. the reblog or the answer), if any.
+	Post_id    string
+	Reply_text string
+	Media_url  string
+}
+
+//Returns the time of the notification.
+func (n Notification) Time() time.Time {
+	return time.Unix(n.Timestamp, 0)
+}
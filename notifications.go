@@ -0,0 +1,170 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//Options of the requests for the notifications of a blog.
+type NotificationOptions struct {
+	//Return only notifications from before this unix timestamp. 0 means from now.
+	//Use NotificationsResponse.Before to get the next page.
+	Before int64
+	//Return only notifications of these types. Empty means all types.
+	Types []NotificationType
+}
+
+//Gets the notifications of one of the user's blogs, newest first.
+//blogname: the name of the blog whose notifications you want to get.
+func (trc *TumblrRestClient) Notifications(ctx context.Context, blogname string, options NotificationOptions) (NotificationsResponse, error) {
+	requestUrl := blogPath(blogname, "/notifications")
+	params := map[string]string{}
+	if options.Before != 0 {
+		params["before"] = strconv.FormatInt(options.Before, 10)
+	}
+	for i, notificationType := range options.Types {
+		params["types["+strconv.Itoa(i)+"]"] = string(notificationType)
+	}
+	data := trc.request.GetContext(ctx, requestUrl, params)
+	var result NotificationsResponse
+	if data.Meta.Status != 200 {
+		return result, errors.New(data.Meta.Msg)
+	}
+	err := json.Unmarshal(data.Response, &result)
+	return result, err
+}
+
+//The interval NotificationStream polls at when none is given.
+const DefaultNotificationInterval = time.Minute
+
+//Polls the notifications of one of the user's blogs and returns the new ones, oldest first.
+//Call Next until it returns false and check Err afterwards:
+//
+//	stream := client.NotificationStream(ctx, "mgterzieva", time.Now(), 30*time.Second)
+//	for stream.Next() {
+//		fmt.Println(stream.Notification().Type)
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+type NotificationStream struct {
+	ctx      context.Context
+	fetch    func(before int64) (NotificationsResponse, error)
+	interval time.Duration
+	polled   bool
+	//The timestamp of the newest notification seen and the notifications seen with it,
+	//so that notifications from the same second are not returned twice.
+	since   int64
+	seen    map[Notification]bool
+	buffer  []Notification
+	current Notification
+	err     error
+}
+
+//Returns a NotificationStream with the notifications of the blog that happen after since.
+//interval: how long to wait between polls; 0 means DefaultNotificationInterval.
+//types: return only notifications of these types. None means all types.
+//The stream ends when ctx is done or a request fails.
+func (trc *TumblrRestClient) NotificationStream(ctx context.Context, blogname string, since time.Time, interval time.Duration, types ...NotificationType) *NotificationStream {
+	if interval <= 0 {
+		interval = DefaultNotificationInterval
+	}
+	return &NotificationStream{
+		ctx: ctx,
+		fetch: func(before int64) (NotificationsResponse, error) {
+			return trc.Notifications(ctx, blogname, NotificationOptions{Before: before, Types: types})
+		},
+		interval: interval,
+		since:    since.Unix(),
+		seen:     map[Notification]bool{},
+	}
+}
+
+//Waits for the next new notification.
+//It returns false when the context is done or a request failed.
+func (s *NotificationStream) Next() bool {
+	if s == nil {
+		return false
+	}
+	for len(s.buffer) == 0 {
+		if s.err != nil {
+			return false
+		}
+		if s.polled {
+			timer := time.NewTimer(s.interval)
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				s.err = s.ctx.Err()
+				return false
+			case <-timer.C:
+			}
+		}
+		s.polled = true
+		s.err = s.poll()
+	}
+	s.current, s.buffer = s.buffer[0], s.buffer[1:]
+	return true
+}
+
+//Returns the current notification.
+func (s *NotificationStream) Notification() Notification {
+	return s.current
+}
+
+//Returns the error that ended the stream, if any.
+func (s *NotificationStream) Err() error {
+	if s == nil {
+		return nil
+	}
+	return s.err
+}
+
+//Requests the notifications newer than the ones seen so far and buffers them, oldest first.
+func (s *NotificationStream) poll() error {
+	fresh := []Notification{}
+	before := int64(0)
+	for {
+		response, err := s.fetch(before)
+		if err != nil {
+			return err
+		}
+		older := false
+		for _, notification := range response.Notifications {
+			if notification.Timestamp < s.since || notification.Timestamp == s.since && s.seen[notificationKey(notification)] {
+				older = true
+				continue
+			}
+			fresh = append(fresh, notification)
+		}
+		before = response.Before()
+		if older || before == 0 || len(response.Notifications) == 0 {
+			break
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].Timestamp < fresh[j].Timestamp
+	})
+	for i := len(fresh) - 1; i >= 0; i-- {
+		notification := fresh[i]
+		if notification.Timestamp > s.since {
+			s.since = notification.Timestamp
+			s.seen = map[Notification]bool{}
+		}
+		if notification.Timestamp == s.since {
+			s.seen[notificationKey(notification)] = true
+		}
+	}
+	s.buffer = append(s.buffer, fresh...)
+	return nil
+}
+
+//Identifies the notification regardless of whether it has been read.
+func notificationKey(notification Notification) Notification {
+	notification.Unread = false
+	return notification
+}
@@ -0,0 +1,25 @@
+package gotumblr
+
+import "strconv"
+
+type NotificationsResponse struct {
+	Notifications []Notification
+	Links         NotificationLinks `json:"_links"`
+}
+
+type NotificationLinks struct {
+	Next NotificationLink
+}
+
+type NotificationLink struct {
+	Href         string
+	Method       string
+	Query_params map[string]string
+}
+
+//Returns the before parameter that gets the next, older page of notifications,
+//or 0 if there are no more.
+func (r NotificationsResponse) Before() int64 {
+	before, _ := strconv.ParseInt(r.Links.Next.Query_params["before"], 10, 64)
+	return before
+}
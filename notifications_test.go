@@ -0,0 +1,97 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotifications(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/notifications", "GET", `{"meta": {"status": 200, "msg": "OK"}, "response": {
+		"notifications": [{"type": "like", "timestamp": 1414713600, "unread": true, "target_post_id": "72078164824", "from_tumblelog_name": "thehungergamesmovie"}],
+		"_links": {"next": {"href": "/v2/blog/mgterzieva/notifications?before=1414713600", "method": "GET", "query_params": {"before": "1414713600"}}}}}`,
+		map[string]string{"before": "1414800000", "types[0]": "like", "types[1]": "reblog_naked"}, t)
+
+	options := NotificationOptions{Before: 1414800000, Types: []NotificationType{NotificationLike, NotificationReblogNaked}}
+	notifications, err := client.Notifications(context.Background(), "mgterzieva", options)
+	if err != nil {
+		t.Fatalf("Notifications returned %+v, want %+v", err, nil)
+	}
+	want := []Notification{{
+		Type:                NotificationLike,
+		Timestamp:           1414713600,
+		Unread:              true,
+		Target_post_id:      "72078164824",
+		From_tumblelog_name: "thehungergamesmovie",
+	}}
+	if !reflect.DeepEqual(notifications.Notifications, want) {
+		t.Errorf("Notifications returned %+v, want %+v", notifications.Notifications, want)
+	}
+	if before := notifications.Before(); before != 1414713600 {
+		t.Errorf("Before returned %v, want %v", before, 1414713600)
+	}
+	if notificationTime := want[0].Time(); !notificationTime.Equal(time.Unix(1414713600, 0)) {
+		t.Errorf("Time returned %v", notificationTime)
+	}
+}
+
+func TestNotificationsError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/thehungergamesmovie/notifications", "GET", `{"meta": {"status": 403, "msg": "Forbidden"}}`, map[string]string{}, t)
+
+	_, err := client.Notifications(context.Background(), "thehungergamesmovie", NotificationOptions{})
+	if !reflect.DeepEqual(err, errors.New("Forbidden")) {
+		t.Errorf("Notifications returned %+v, want Forbidden", err)
+	}
+}
+
+func TestNotificationStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc("/v2/blog/mgterzieva/notifications", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		notifications := `{"type": "follow", "timestamp": 100, "from_tumblelog_name": "old"}`
+		if polls >= 2 {
+			notifications = `{"type": "like", "timestamp": 200, "from_tumblelog_name": "second"},
+				{"type": "follow", "timestamp": 200, "from_tumblelog_name": "first"},` + notifications
+		}
+		if polls >= 3 {
+			notifications = `{"type": "reblog_naked", "timestamp": 200, "from_tumblelog_name": "third"},` + notifications
+		}
+		fmt.Fprintf(w, `{"meta": {"status": 200, "msg": "OK"}, "response": {"notifications": [%s]}}`, notifications)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := client.NotificationStream(ctx, "mgterzieva", time.Unix(150, 0), time.Millisecond)
+	names := []string{}
+	for len(names) < 3 && stream.Next() {
+		names = append(names, stream.Notification().From_tumblelog_name)
+	}
+	if want := []string{"second", "first", "third"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NotificationStream returned %v, want %v", names, want)
+	}
+
+	cancel()
+	if stream.Next() {
+		t.Errorf("Next returned %v after the context was canceled", true)
+	}
+	if stream.Err() != context.Canceled {
+		t.Errorf("Err returned %+v, want %+v", stream.Err(), context.Canceled)
+	}
+}
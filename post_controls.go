@@ -0,0 +1,74 @@
+package gotumblr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//Returns an error unless id is a post id, so that it can't change the path or the parameters of a request.
+func checkPostId(id string) error {
+	if !isId(id) {
+		return fmt.Errorf("gotumblr: invalid post id %q", id)
+	}
+	return nil
+}
+
+//Mutes the notifications about a post of the blog.
+//id: the id of the post; other strings are rejected.
+//duration: how long to mute the post for; 0 mutes it until it is unmuted.
+func (trc *TumblrRestClient) MutePost(blogname, id string, duration time.Duration) error {
+	if err := checkPostId(id); err != nil {
+		return err
+	}
+	requestUrl := blogPath(blogname, "/posts/"+id+"/mute")
+	params := map[string]string{"mute_length": strconv.FormatInt(int64(duration/time.Second), 10)}
+	data := trc.request.Post(requestUrl, params)
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Unmutes the notifications about a post of the blog.
+//id: the id of the post; other strings are rejected.
+func (trc *TumblrRestClient) UnmutePost(blogname, id string) error {
+	if err := checkPostId(id); err != nil {
+		return err
+	}
+	requestUrl := blogPath(blogname, "/posts/"+id+"/mute")
+	data := trc.request.Delete(requestUrl, map[string]string{})
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Pins a post to the top of the blog, unpinning the one that was pinned before.
+//id: the id of the post; other strings are rejected.
+func (trc *TumblrRestClient) PinPost(blogname, id string) error {
+	if err := checkPostId(id); err != nil {
+		return err
+	}
+	requestUrl := blogPath(blogname, "/pin")
+	data := trc.request.Post(requestUrl, map[string]string{"id": id})
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Unpins a post from the top of the blog.
+//id: the id of the post; other strings are rejected.
+func (trc *TumblrRestClient) UnpinPost(blogname, id string) error {
+	if err := checkPostId(id); err != nil {
+		return err
+	}
+	requestUrl := blogPath(blogname, "/pin")
+	data := trc.request.Delete(requestUrl, map[string]string{"id": id})
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMutePost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/72078164824/mute", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"mute_length": "86400"}, t)
+
+	if err := client.MutePost("mgterzieva", "72078164824", 24*time.Hour); err != nil {
+		t.Errorf("MutePost returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestUnmutePost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/72078164824/mute", "DELETE", `{"meta": {"status": 404, "msg": "Not Found"}}`, map[string]string{}, t)
+
+	err := client.UnmutePost("mgterzieva", "72078164824")
+	if !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("UnmutePost returned %+v, want Not Found", err)
+	}
+}
+
+func TestPinPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/pin", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "72078164824"}, t)
+
+	if err := client.PinPost("mgterzieva", "72078164824"); err != nil {
+		t.Errorf("PinPost returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestUnpinPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/pin", "DELETE", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "72078164824"}, t)
+
+	if err := client.UnpinPost("mgterzieva", "72078164824"); err != nil {
+		t.Errorf("UnpinPost returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestInvalidPostId(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if err := client.MutePost("mgterzieva", "1/../../info", time.Hour); err == nil || err.Error() != `gotumblr: invalid post id "1/../../info"` {
+		t.Errorf("MutePost returned %+v, want an invalid post id error", err)
+	}
+	if err := client.PinPost("mgterzieva", ""); err == nil {
+		t.Errorf("PinPost returned %+v, want an error", err)
+	}
+}
+
+func TestSetCommunityLabel(t *testing.T) {
+	options := map[string]string{"body": "Hello"}
+	SetCommunityLabel(options, CommunityLabelViolence, CommunityLabelDrugUse)
+	want := map[string]string{
+		"body":                          "Hello",
+		"has_community_label":           "true",
+		"community_label_categories[0]": "violence",
+		"community_label_categories[1]": "drug_use",
+	}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("SetCommunityLabel set %+v, want %+v", options, want)
+	}
+}
+
+func TestCommunityLabelFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts", "GET", `{"response": {"posts": [{"id": 1, "is_pinned": true, "has_community_label": true, "community_label_categories": ["violence"]}]}}`, map[string]string{}, t)
+
+	var post BasePost
+	json.Unmarshal(client.Posts("mgterzieva", "", map[string]string{}).Posts[0], &post)
+	if !post.Is_pinned || !post.Has_community_label || !reflect.DeepEqual(post.Community_label_categories, []CommunityLabelCategory{CommunityLabelViolence}) {
+		t.Errorf("Posts returned %+v", post)
+	}
+}
@@ -0,0 +1,71 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+//Iterates over all published posts of the blog, requesting them a page at a time.
+//postsType and options are the ones of Posts (e.g. tag); the offset and limit options are set by the iterator.
+func (trc *TumblrRestClient) PostsIterator(ctx context.Context, blogname, postsType string, options map[string]string) *PostIterator {
+	requestUrl := blogPath(blogname, "/posts")
+	if postsType != "" {
+		requestUrl = blogPath(blogname, "/posts/"+postsType)
+	}
+	params := map[string]string{"api_key": trc.request.apiKey}
+	for key, value := range options {
+		params[key] = value
+	}
+	return &PostIterator{pager[json.RawMessage]{fetch: offsetPages(ctx, trc, requestUrl, params, func(result *PostsResponse) []json.RawMessage {
+		return result.Posts
+	})}}
+}
+
+//Iterates over all posts in the blog's queue, requesting them a page at a time.
+func (trc *TumblrRestClient) QueueIterator(ctx context.Context, blogname string) *PostIterator {
+	requestUrl := blogPath(blogname, "/posts/queue")
+	return &PostIterator{pager[json.RawMessage]{fetch: offsetPages(ctx, trc, requestUrl, nil, func(result *DraftsResponse) []json.RawMessage {
+		return result.Posts
+	})}}
+}
+
+//Iterates over all posts in the blog's drafts, requesting them a page at a time.
+//Drafts are paginated by the id of the last draft of the previous page instead of an offset.
+func (trc *TumblrRestClient) DraftsIterator(ctx context.Context, blogname string) *PostIterator {
+	requestUrl := blogPath(blogname, "/posts/draft")
+	var beforeId int64
+	return &PostIterator{pager[json.RawMessage]{fetch: func(int) ([]json.RawMessage, error) {
+		params := map[string]string{}
+		if beforeId != 0 {
+			params["before_id"] = strconv.FormatInt(beforeId, 10)
+		}
+		var result DraftsResponse
+		if err := trc.page(ctx, requestUrl, params, 0, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Posts) != 0 {
+			var last BasePost
+			json.Unmarshal(result.Posts[len(result.Posts)-1], &last)
+			beforeId = last.Id
+		}
+		return result.Posts, nil
+	}}}
+}
+
+//Iterates over all submissions to the blog, requesting them a page at a time.
+func (trc *TumblrRestClient) SubmissionsIterator(ctx context.Context, blogname string) *PostIterator {
+	requestUrl := blogPath(blogname, "/posts/submission")
+	return &PostIterator{pager[json.RawMessage]{fetch: offsetPages(ctx, trc, requestUrl, nil, func(result *DraftsResponse) []json.RawMessage {
+		return result.Posts
+	})}}
+}
+
+//Iterates over all posts the blog has liked, requesting them a page at a time.
+func (trc *TumblrRestClient) BlogLikesIterator(ctx context.Context, blogname string) *PostIterator {
+	requestUrl := blogPath(blogname, "/likes")
+	params := map[string]string{"api_key": trc.request.apiKey}
+	return &PostIterator{pager[json.RawMessage]{fetch: offsetPages(ctx, trc, requestUrl, params, func(result *LikesResponse) []json.RawMessage {
+		return result.Liked_posts
+	})}}
+}
@@ -0,0 +1,43 @@
+package gotumblr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestPostsIteratorPaging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v2/blog/mgterzieva/posts", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.FormValue("offset"))
+		if r.FormValue("limit") != "20" || r.FormValue("tag") != "cats" {
+			t.Errorf("limit = %v and tag = %v, want 20 and cats", r.FormValue("limit"), r.FormValue("tag"))
+		}
+		posts := ""
+		for i := offset; i < offset+20 && i < 25; i++ {
+			if posts != "" {
+				posts += ","
+			}
+			posts += fmt.Sprintf(`{"id": %d}`, i)
+		}
+		fmt.Fprintf(w, `{"meta": {"status": 200, "msg": "OK"}, "response": {"posts": [%s]}}`, posts)
+	})
+
+	for _, options := range []map[string]string{{"tag": "cats", "offset": "0"}, {"tag": "cats", "limit": "5"}} {
+		requests = 0
+		it := client.PostsIterator(context.Background(), "mgterzieva", "", options)
+		count := 0
+		for it.Next() && count <= 25 {
+			count++
+		}
+		if it.Err() != nil || count != 25 || requests != 2 {
+			t.Errorf("PostsIterator with %v returned %v posts in %v requests, %v, want 25 posts in 2 requests", options, count, requests, it.Err())
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+//The result of a request that creates, reblogs or edits a post.
+type PostResult struct {
+	//The id of the post.
+	ID string
+	//The state of the post (e.g. published or queued), if the API returned it.
+	State string
+	//A message about the post (e.g. Posted to mgterzieva), if the API returned it.
+	DisplayText string
+}
+
+//Parses the result from the response, whose id may be a number or a string.
+func (r *PostResult) UnmarshalJSON(content []byte) error {
+	var result struct {
+		Id           json.Number
+		State        string
+		Display_text string
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return err
+	}
+	*r = PostResult{ID: result.Id.String(), State: result.State, DisplayText: result.Display_text}
+	return nil
+}
+
+//Returns the result of a request that creates, reblogs or edits a post,
+//or an error if the response doesn't have the given status.
+func postResult(data CompleteResponse, status int64) (PostResult, error) {
+	var result PostResult
+	if data.Meta.Status != status {
+		return result, errors.New(data.Meta.Msg)
+	}
+	if len(data.Response) != 0 {
+		if err := json.Unmarshal(data.Response, &result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
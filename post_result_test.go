@@ -0,0 +1,30 @@
+package gotumblr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPostResult(t *testing.T) {
+	tests := []struct {
+		response string
+		want     PostResult
+	}{
+		{`{"meta": {"status": 201, "msg": "Created"}, "response": {"id": 72078164824}}`, PostResult{ID: "72078164824"}},
+		{`{"meta": {"status": 201, "msg": "Created"}, "response": {"id": "72078164824", "state": "queued", "display_text": "Queued on mgterzieva"}}`,
+			PostResult{ID: "72078164824", State: "queued", DisplayText: "Queued on mgterzieva"}},
+		{`{"meta": {"status": 201, "msg": "Created"}}`, PostResult{}},
+	}
+	for _, test := range tests {
+		result, err := postResult(client.request.JSONParse([]byte(test.response)), 201)
+		if err != nil || result != test.want {
+			t.Errorf("postResult(%v) returned %+v, %+v, want %+v", test.response, result, err, test.want)
+		}
+	}
+
+	_, err := postResult(client.request.JSONParse([]byte(`{"meta": {"status": 200, "msg": "OK"}}`)), 201)
+	if !reflect.DeepEqual(err, errors.New("OK")) {
+		t.Errorf("postResult returned %+v, want OK", err)
+	}
+}
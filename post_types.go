@@ -44,8 +44,10 @@ type ChatPost struct {
 
 type AudioPost struct {
 	BasePost
-	Caption      string
-	Player       string
+	Caption string
+	Player  string
+	//The url of the audio file, if it is hosted on Tumblr.
+	Audio_url    string
 	Plays        int64
 	Album_art    string
 	Artist       string
@@ -64,6 +66,9 @@ type VideoPost struct {
 	BasePost
 	Caption string
 	Player  []PlayerInfo
+	//The url of the video file and of its thumbnail, if the video is hosted on Tumblr.
+	Video_url     string
+	Thumbnail_url string
 }
 
 type AnswerPost struct {
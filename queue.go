@@ -0,0 +1,58 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+//Gets the posts that are currently in the blog's queue, in the order they will be published.
+//See Queue for the options that can be used.
+func (trc *TumblrRestClient) QueuedPosts(blogname string, options map[string]string) []QueuedPost {
+	posts := []QueuedPost{}
+	for _, raw := range trc.Queue(blogname, options).Posts {
+		var post QueuedPost
+		if err := json.Unmarshal(raw, &post); err == nil {
+			posts = append(posts, post)
+		}
+	}
+	return posts
+}
+
+//Moves a post within the blog's queue.
+//postId: the id of the queued post to move.
+//insertAfter: the id of the queued post to put it after, or "0" to move it to the top of the queue.
+func (trc *TumblrRestClient) ReorderQueue(blogname, postId, insertAfter string) error {
+	requestUrl := blogPath(blogname, "/posts/queue/reorder")
+	params := map[string]string{"post_id": postId, "insert_after": insertAfter}
+	data := trc.request.Post(requestUrl, params)
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Shuffles the posts in the blog's queue into a random order.
+func (trc *TumblrRestClient) ShuffleQueue(blogname string) error {
+	requestUrl := blogPath(blogname, "/posts/queue/shuffle")
+	data := trc.request.Post(requestUrl, map[string]string{})
+	if data.Meta.Status != 200 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Moves a draft to the end of the blog's queue.
+//id: the id of the draft.
+func (trc *TumblrRestClient) QueueDraft(blogname, id string) error {
+	_, err := trc.EditPost(blogname, map[string]string{"id": id, "state": "queue"})
+	return err
+}
+
+//Queues a post to be published at the given time instead of at its turn in the queue.
+//id: the id of the draft or queued post.
+//publishOn: when the post should be published.
+func (trc *TumblrRestClient) SchedulePost(blogname, id string, publishOn time.Time) error {
+	_, err := trc.EditPost(blogname, map[string]string{"id": id, "state": "queue", "publish_on": publishOn.UTC().Format(time.RFC3339)})
+	return err
+}
@@ -0,0 +1,72 @@
+package gotumblr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueuedPosts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/queue", "GET", `{"response": {"posts": [{"id": 3, "state": "queued", "scheduled_publish_time": 1414713600}]}}`, map[string]string{}, t)
+
+	posts := client.QueuedPosts("mgterzieva", map[string]string{})
+	if len(posts) != 1 || posts[0].Id != 3 || posts[0].State != "queued" {
+		t.Fatalf("QueuedPosts returned %+v", posts)
+	}
+	if publishTime := posts[0].PublishTime(); !publishTime.Equal(time.Unix(1414713600, 0)) {
+		t.Errorf("PublishTime returned %v", publishTime)
+	}
+	if publishTime := (QueuedPost{}).PublishTime(); !publishTime.IsZero() {
+		t.Errorf("PublishTime returned %v, want the zero time", publishTime)
+	}
+}
+
+func TestReorderQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/queue/reorder", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"post_id": "3", "insert_after": "0"}, t)
+
+	if err := client.ReorderQueue("mgterzieva", "3", "0"); err != nil {
+		t.Errorf("ReorderQueue returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestShuffleQueue(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/queue/shuffle", "POST", `{"meta": {"status": 403, "msg": "Forbidden"}}`, map[string]string{}, t)
+
+	err := client.ShuffleQueue("mgterzieva")
+	if !reflect.DeepEqual(err, errors.New("Forbidden")) {
+		t.Errorf("ShuffleQueue returned %+v, want Forbidden", err)
+	}
+}
+
+func TestQueueDraft(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "3", "state": "queue"}, t)
+
+	if err := client.QueueDraft("mgterzieva", "3"); err != nil {
+		t.Errorf("QueueDraft returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestSchedulePost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "3", "state": "queue", "publish_on": "2014-10-31T00:00:00Z"}, t)
+
+	publishOn := time.Date(2014, 10, 31, 2, 0, 0, 0, time.FixedZone("EET", 2*60*60))
+	if err := client.SchedulePost("mgterzieva", "3", publishOn); err != nil {
+		t.Errorf("SchedulePost returned %+v, want %+v", err, nil)
+	}
+}
@@ -0,0 +1,19 @@
+package gotumblr
+
+import "time"
+
+//A post in a blog's queue.
+type QueuedPost struct {
+	BasePost
+	//The unix timestamp of when the post is scheduled to be published.
+	Scheduled_publish_time int64
+}
+
+//Returns when the post is scheduled to be published,
+//or the zero time if the API did not say.
+func (p QueuedPost) PublishTime() time.Time {
+	if p.Scheduled_publish_time == 0 {
+		return time.Time{}
+	}
+	return time.Unix(p.Scheduled_publish_time, 0)
+}
@@ -0,0 +1,78 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Options of ReblogPost.
+type ReblogOptions struct {
+	//A comment added to the reblog, as plain text.
+	Comment string
+	//Neue Post Format content blocks added to the reblog after the comment
+	//(e.g. {"type": "image", "media": [...]}).
+	Content []json.RawMessage
+	//The tags of the reblog.
+	Tags []string
+	//The state of the reblog: published (the default), queue, draft or private.
+	State string
+	//Whether to leave the posts that were reblogged out of the reblog.
+	HideTrail bool
+	//The indexes of the items of the reblog trail to leave out of the reblog.
+	ExcludeTrailItems []int
+}
+
+//The body of a Neue Post Format request that creates a post.
+type npfPost struct {
+	Content             []json.RawMessage `json:"content"`
+	Tags                string            `json:"tags,omitempty"`
+	State               string            `json:"state,omitempty"`
+	ParentTumblelogUuid string            `json:"parent_tumblelog_uuid,omitempty"`
+	ParentPostId        string            `json:"parent_post_id,omitempty"`
+	ReblogKey           string            `json:"reblog_key,omitempty"`
+	HideTrail           bool              `json:"hide_trail,omitempty"`
+	ExcludeTrailItems   []int             `json:"exclude_trail_items,omitempty"`
+}
+
+//Reblogs a post to a blog using the Neue Post Format. The ID of the result is the id of the reblog.
+//targetBlog: the blog you want to reblog to.
+//sourcePost: the post to reblog, e.g. a TextPost or a BasePost from a list of posts.
+//Its Id, Reblog_key and blog are sent as the parent of the reblog;
+//the blog name is used when the post has no blog uuid.
+func (trc *TumblrRestClient) ReblogPost(ctx context.Context, targetBlog string, sourcePost Post, options ReblogOptions) (PostResult, error) {
+	parent := sourcePost.Base()
+	if parent.Id == 0 || parent.Reblog_key == "" {
+		return PostResult{}, errors.New("gotumblr: the post to reblog has no id or reblog key")
+	}
+	if len(options.Tags) != 0 {
+		errs := &ValidationError{}
+		validateTags(errs, options.Tags)
+		if err := errs.err(); err != nil {
+			return PostResult{}, err
+		}
+	}
+	parentBlog := parent.Blog.Uuid
+	if parentBlog == "" {
+		parentBlog = parent.Blog_name
+	}
+	body := npfPost{
+		Content:             []json.RawMessage{},
+		Tags:                strings.Join(options.Tags, ","),
+		State:               options.State,
+		ParentTumblelogUuid: parentBlog,
+		ParentPostId:        fmt.Sprint(parent.Id),
+		ReblogKey:           parent.Reblog_key,
+		HideTrail:           options.HideTrail,
+		ExcludeTrailItems:   options.ExcludeTrailItems,
+	}
+	if options.Comment != "" {
+		comment, _ := json.Marshal(map[string]string{"type": "text", "text": options.Comment})
+		body.Content = append(body.Content, comment)
+	}
+	body.Content = append(body.Content, options.Content...)
+	data := trc.request.PostJSON(ctx, blogPath(targetBlog, "/posts"), body)
+	return postResult(data, 201)
+}
@@ -0,0 +1,81 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestReblogPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/posts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Request method = %v %v, want POST application/json", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var got map[string]interface{}
+		json.Unmarshal(body, &got)
+		want := map[string]interface{}{
+			"content": []interface{}{
+				map[string]interface{}{"type": "text", "text": "So true!"},
+				map[string]interface{}{"type": "text", "text": "xoxo", "subtype": "quote"},
+			},
+			"tags":                  "love,xoxo",
+			"state":                 "queue",
+			"parent_tumblelog_uuid": "t:mXE9HJEYtfl1MvIl6HJRJQ",
+			"parent_post_id":        "72078164824",
+			"reblog_key":            "6l3e2pGL",
+			"exclude_trail_items":   []interface{}{float64(0)},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ReblogPost sent %+v, want %+v", got, want)
+		}
+		fmt.Fprint(w, `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": "72078164999"}}`)
+	})
+
+	post := TextPost{BasePost: BasePost{Id: 72078164824, Reblog_key: "6l3e2pGL", Blog_name: "thehungergamesmovie", Blog: PostBlog{Uuid: "t:mXE9HJEYtfl1MvIl6HJRJQ"}}}
+	options := ReblogOptions{
+		Comment:           "So true!",
+		Content:           []json.RawMessage{json.RawMessage(`{"type": "text", "text": "xoxo", "subtype": "quote"}`)},
+		Tags:              []string{"love", "xoxo"},
+		State:             "queue",
+		ExcludeTrailItems: []int{0},
+	}
+	result, err := client.ReblogPost(context.Background(), "mgterzieva", post, options)
+	if err != nil || result.ID != "72078164999" {
+		t.Errorf("ReblogPost returned %+v, %+v, want 72078164999", result, err)
+	}
+}
+
+func TestReblogPostWithoutUuid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/posts", func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got["parent_tumblelog_uuid"] != "thehungergamesmovie" || got["hide_trail"] != true {
+			t.Errorf("ReblogPost sent %+v", got)
+		}
+		fmt.Fprint(w, `{"meta": {"status": 400, "msg": "Bad Request"}}`)
+	})
+
+	post := BasePost{Id: 72078164824, Reblog_key: "6l3e2pGL", Blog_name: "thehungergamesmovie"}
+	_, err := client.ReblogPost(context.Background(), "mgterzieva", post, ReblogOptions{HideTrail: true})
+	if !reflect.DeepEqual(err, errors.New("Bad Request")) {
+		t.Errorf("ReblogPost returned %+v, want Bad Request", err)
+	}
+}
+
+func TestReblogPostWithoutReblogKey(t *testing.T) {
+	if _, err := client.ReblogPost(context.Background(), "mgterzieva", BasePost{Id: 1}, ReblogOptions{}); err == nil {
+		t.Errorf("ReblogPost returned %+v, want an error", err)
+	}
+}
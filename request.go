@@ -3,11 +3,17 @@
 package gotumblr
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/kurrik/oauth1a"
@@ -19,6 +25,11 @@ type TumblrRequest struct {
 	userConfig *oauth1a.UserConfig
 	host       string
 	apiKey     string
+	httpClient *http.Client
+	middleware []Middleware
+	logger     *slog.Logger
+	cache      *cacheLayer
+	coalescing *flightGroup
 }
 
 //Initializes the TumblrRequest.
@@ -41,67 +52,246 @@ func NewTumblrRequest(consumerKey, consumerSecret, oauthToken, oauthSecret, call
 		Signer: new(oauth1a.HmacSha1Signer),
 	}
 	userConfig := oauth1a.NewAuthorizedConfig(oauthToken, oauthSecret)
-	return &TumblrRequest{service, userConfig, host, consumerKey}
+	return &TumblrRequest{
+		service:    service,
+		userConfig: userConfig,
+		host:       host,
+		apiKey:     consumerKey,
+		httpClient: new(http.Client),
+	}
+}
+
+//Sets the http.RoundTripper used to send the requests (e.g. a recording or replaying one).
+//transport: nil means http.DefaultTransport.
+func (tr *TumblrRequest) SetTransport(transport http.RoundTripper) {
+	tr.httpClient.Transport = transport
+}
+
+//Returns the http.RoundTripper used to send the requests.
+func (tr *TumblrRequest) transport() http.RoundTripper {
+	if tr.httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+	return tr.httpClient.Transport
 }
 
 //Make a GET request to the API with properly formatted parameters.
 //requestUrl: the url you are making the request to.
 //params: the parameters needed for the request.
 func (tr *TumblrRequest) Get(requestUrl string, params map[string]string) CompleteResponse {
-	fullUrl := tr.host + requestUrl
-	if len(params) != 0 {
-		values := url.Values{}
-		for key, value := range params {
+	return tr.call(context.Background(), "GET", requestUrl, params)
+}
+
+//Makes a GET request to the API like Get. The request is canceled when ctx is done.
+func (tr *TumblrRequest) GetContext(ctx context.Context, requestUrl string, params map[string]string) CompleteResponse {
+	return tr.call(ctx, "GET", requestUrl, params)
+}
+
+//Makes a POST request to the API, allows for multipart data uploads.
+//requestUrl: the url you are making the request to.
+//params: all the parameters needed for the request.
+func (tr *TumblrRequest) Post(requestUrl string, params map[string]string) CompleteResponse {
+	return tr.call(context.Background(), "POST", requestUrl, params)
+}
+
+//Makes a POST request to the API like Post. The request is canceled when ctx is done.
+func (tr *TumblrRequest) PostContext(ctx context.Context, requestUrl string, params map[string]string) CompleteResponse {
+	return tr.call(ctx, "POST", requestUrl, params)
+}
+
+//Makes a DELETE request to the API, sending the parameters in the query string.
+//requestUrl: the url you are making the request to.
+//params: the parameters needed for the request.
+func (tr *TumblrRequest) Delete(requestUrl string, params map[string]string) CompleteResponse {
+	return tr.call(context.Background(), "DELETE", requestUrl, params)
+}
+
+//Makes a DELETE request to the API like Delete. The request is canceled when ctx is done.
+func (tr *TumblrRequest) DeleteContext(ctx context.Context, requestUrl string, params map[string]string) CompleteResponse {
+	return tr.call(ctx, "DELETE", requestUrl, params)
+}
+
+//Makes a POST request to the API with a JSON body, as the endpoints of the Neue Post Format require.
+//requestUrl: the url you are making the request to.
+//body: the value to send, encoded as JSON.
+func (tr *TumblrRequest) PostJSON(ctx context.Context, requestUrl string, body interface{}) CompleteResponse {
+	call := tr.newCall(ctx, "POST", requestUrl, map[string]string{})
+	content, err := json.Marshal(body)
+	if err != nil {
+		return tr.fail(call, err)
+	}
+	call.Body = content
+	call.ContentType = "application/json"
+	return tr.run(call)
+}
+
+//A file uploaded with PostMultipart.
+type UploadFile struct {
+	//The name of the form field the file is sent as (e.g. data[0]).
+	Field string
+	//The name of the file (e.g. cat.jpg).
+	Name    string
+	Content []byte
+}
+
+//Makes a POST request to the API with a multipart body, as uploading files requires.
+//params are sent as fields of the body, which OAuth doesn't sign, followed by the files.
+func (tr *TumblrRequest) PostMultipart(ctx context.Context, requestUrl string, params map[string]string, files []UploadFile) CompleteResponse {
+	call := tr.newCall(ctx, "POST", requestUrl, params)
+	call.files = append([]UploadFile{}, files...)
+	return tr.run(call)
+}
+
+//Encodes the parameters, sorted by name, and the files as a multipart body
+//and returns it along with its content type.
+func multipartBody(params map[string]string, files []UploadFile) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := writer.WriteField(key, params[key]); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		part.Write(file.Content)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
+//Sends the HTTP request described by the call, after it has passed through all middleware.
+func (tr *TumblrRequest) send(call *APICall) CompleteResponse {
+	requestBody, contentType := call.Body, call.ContentType
+	values := url.Values{}
+	if call.files != nil {
+		var err error
+		if requestBody, contentType, err = multipartBody(call.Params, call.files); err != nil {
+			return tr.fail(call, err)
+		}
+	} else {
+		for key, value := range call.Params {
 			values.Set(key, value)
 		}
-		fullUrl = fullUrl + "?" + values.Encode()
 	}
-	httpRequest, err := http.NewRequest("GET", fullUrl, nil)
+	fullUrl := tr.host + call.Endpoint
+	if call.download {
+		fullUrl = call.Endpoint
+	}
+	var httpRequest *http.Request
+	var err error
+	if call.Method == "GET" || call.Method == "DELETE" || requestBody != nil {
+		if len(values) != 0 {
+			fullUrl = fullUrl + "?" + values.Encode()
+		}
+		var body io.Reader
+		if requestBody != nil {
+			body = bytes.NewReader(requestBody)
+		}
+		httpRequest, err = http.NewRequestWithContext(call.Context, call.Method, fullUrl, body)
+	} else {
+		httpRequest, err = http.NewRequestWithContext(call.Context, call.Method, fullUrl, strings.NewReader(values.Encode()))
+	}
 	if err != nil {
-		fmt.Println(err)
+		return tr.fail(call, err)
+	}
+	for key, headerValues := range call.Header {
+		httpRequest.Header[key] = headerValues
+	}
+	if requestBody != nil {
+		httpRequest.Header.Set("Content-Type", contentType)
+	} else if call.Method == "POST" {
+		httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
-	tr.service.Sign(httpRequest, tr.userConfig)
-	var httpResponse *http.Response
-	httpClient := new(http.Client)
-	httpResponse, err2 := httpClient.Do(httpRequest)
-	if err2 != nil {
-		fmt.Println(err2)
+	if !call.download {
+		tr.service.Sign(httpRequest, tr.userConfig)
+	}
+	httpClient := tr.httpClient
+	if call.noRedirect {
+		httpClient = &http.Client{
+			Transport: tr.httpClient.Transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return tr.fail(call, err)
+	}
+	call.StatusCode = httpResponse.StatusCode
+	call.ResponseHeader = httpResponse.Header
+	if call.download {
+		if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusPartialContent {
+			httpResponse.Body.Close()
+			httpResponse.Body = http.NoBody
+		}
+		call.httpResponse = httpResponse
+		return CompleteResponse{Meta: MetaInfo{Status: int64(httpResponse.StatusCode), Msg: http.StatusText(httpResponse.StatusCode)}}
 	}
 	defer httpResponse.Body.Close()
-	body, err3 := ioutil.ReadAll(httpResponse.Body)
-	if err3 != nil {
-		fmt.Println(err3)
+	if httpResponse.StatusCode == http.StatusNotModified {
+		return CompleteResponse{}
+	}
+	if call.noRedirect && !strings.Contains(httpResponse.Header.Get("Content-Type"), "json") {
+		if httpResponse.StatusCode/100 == 3 || strings.HasPrefix(httpResponse.Header.Get("Content-Type"), "image/") {
+			return redirectResponse(httpResponse)
+		}
+	}
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return tr.fail(call, err)
 	}
 	return tr.JSONParse(body)
 }
 
-//Makes a POST request to the API, allows for multipart data uploads.
-//requestUrl: the url you are making the request to.
-//params: all the parameters needed for the request.
-func (tr *TumblrRequest) Post(requestUrl string, params map[string]string) CompleteResponse {
-	full_url := tr.host + requestUrl
-	values := url.Values{}
-	for key, value := range params {
-		values.Set(key, value)
+//Downloads the file at fileUrl through the middleware chain, with the given additional headers.
+//The body of the returned response is open if the status is 200 or 206; the caller must close it.
+func (tr *TumblrRequest) download(ctx context.Context, fileUrl string, header http.Header) (*http.Response, error) {
+	call := tr.newCall(ctx, "GET", fileUrl, map[string]string{})
+	call.Name = "download"
+	if header != nil {
+		call.Header = header
 	}
-	httpRequest, err := http.NewRequest("POST", full_url, strings.NewReader(values.Encode()))
-	if err != nil {
-		fmt.Println(err)
+	call.download = true
+	tr.run(call)
+	if call.Err != nil {
+		if call.httpResponse != nil {
+			call.httpResponse.Body.Close()
+		}
+		return nil, call.Err
 	}
-	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	tr.service.Sign(httpRequest, tr.userConfig)
-	var httpResponse *http.Response
-	httpClient := new(http.Client)
-	httpResponse, err2 := httpClient.Do(httpRequest)
-	if err2 != nil {
-		fmt.Println(err2)
+	if call.httpResponse == nil {
+		return nil, fmt.Errorf("gotumblr: downloading %s: no response", fileUrl)
 	}
-	defer httpResponse.Body.Close()
-	body, err3 := ioutil.ReadAll(httpResponse.Body)
-	if err3 != nil {
-		fmt.Println(err3)
+	return call.httpResponse, nil
+}
+
+//Describes a response that is not JSON, such as a redirect, as an API response.
+//The Location of redirects is returned as the location field of the response.
+func redirectResponse(httpResponse *http.Response) CompleteResponse {
+	data := CompleteResponse{Meta: MetaInfo{Status: int64(httpResponse.StatusCode), Msg: http.StatusText(httpResponse.StatusCode)}}
+	if location, err := httpResponse.Location(); err == nil {
+		data.Response, _ = json.Marshal(map[string]string{"location": location.String()})
 	}
-	return tr.JSONParse(body)
+	return data
+}
+
+//Records an error that prevented the call from getting a response from the API.
+//The returned response carries the error message in its meta information.
+func (tr *TumblrRequest) fail(call *APICall, err error) CompleteResponse {
+	call.Err = err
+	return CompleteResponse{Meta: MetaInfo{Msg: err.Error()}}
 }
 
 //Parse JSON response.
@@ -110,7 +300,7 @@ func (tr *TumblrRequest) JSONParse(content []byte) CompleteResponse {
 	var data CompleteResponse
 	err := json.Unmarshal(content, &data)
 	if err != nil {
-		fmt.Println(err)
+		tr.logError("gotumblr: parsing response", err)
 	}
 	return data
 }
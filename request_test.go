@@ -1,8 +1,12 @@
 package gotumblr
 
 import (
-	"testing"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
 )
 
 func TestNewTumblrRequest(t *testing.T) {
@@ -64,4 +68,43 @@ func TestJSONParse(t *testing.T) {
 	if response.User.Name != expected_name {
 		t.Errorf("Get returned %v, want %v", response.User.Name, expected_name)
 	} 
-}
\ No newline at end of file
+}
+
+func TestPostMultipart(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/blog/mgterzieva/post", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm returned %+v", err)
+		}
+		if r.FormValue("type") != "photo" || r.FormValue("caption") != "a cat" || r.URL.RawQuery != "" {
+			t.Errorf("type = %v, caption = %v and the query is %q, want the params of the middleware in the body only", r.FormValue("type"), r.FormValue("caption"), r.URL.RawQuery)
+		}
+		file, header, err := r.FormFile("data[0]")
+		if err != nil {
+			t.Fatalf("FormFile returned %+v", err)
+		}
+		content, _ := ioutil.ReadAll(file)
+		if header.Filename != "cat.jpg" || string(content) != "a cat" {
+			t.Errorf("uploaded %v with %q, want cat.jpg with %q", header.Filename, content, "a cat")
+		}
+		fmt.Fprint(w, `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": 7}}`)
+	})
+	var params map[string]string
+	client.Use(func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			params = call.Params
+			call.Params["caption"] = "a cat"
+			return next(call)
+		}
+	})
+	files := []UploadFile{{Field: "data[0]", Name: "cat.jpg", Content: []byte("a cat")}}
+	data := client.request.PostMultipart(context.Background(), "/v2/blog/mgterzieva/post", map[string]string{"type": "photo"}, files)
+	if data.Meta.Status != 201 {
+		t.Errorf("PostMultipart returned %+v", data.Meta)
+	}
+	if params["type"] != "photo" {
+		t.Errorf("the middleware saw the params %v, want the type", params)
+	}
+}
@@ -0,0 +1,442 @@
+package gotumblr
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//The number of posts on a page of a site when SiteOptions.PostsPerPage is not set.
+const DefaultSitePostsPerPage = 10
+
+//The templates RenderSite renders pages with, in the syntax of html/template.
+//"page" renders a page, with a SitePage, and "post" renders a post of it, with a SitePost,
+//using the template named after the type of the post (text, photo, quote, link, chat, audio, video or answer).
+//The bodies and captions of posts are HTML and are rendered as it is with the safe function.
+const DefaultSiteTemplates = `{{define "page"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if .Tag}}#{{.Tag}} - {{end}}{{.Title}}</title>
+</head>
+<body>
+<header>
+<h1><a href="{{.Root}}index.html">{{.Title}}</a></h1>
+{{if .Tag}}<h2>Posts tagged #{{.Tag}}</h2>{{end}}
+</header>
+<main>
+{{range .Posts}}{{template "post" .}}{{end}}
+</main>
+{{if or .Newer .Older}}<nav>
+{{with .Newer}}<a rel="prev" href="{{.}}">Newer posts</a>{{end}}
+{{with .Older}}<a rel="next" href="{{.}}">Older posts</a>{{end}}
+</nav>{{end}}
+</body>
+</html>
+{{end}}
+
+{{define "post"}}<article class="post {{.Type}}">
+{{if eq .Type "text"}}{{template "text" .}}
+{{else if eq .Type "photo"}}{{template "photo" .}}
+{{else if eq .Type "quote"}}{{template "quote" .}}
+{{else if eq .Type "link"}}{{template "link" .}}
+{{else if eq .Type "chat"}}{{template "chat" .}}
+{{else if eq .Type "audio"}}{{template "audio" .}}
+{{else if eq .Type "video"}}{{template "video" .}}
+{{else if eq .Type "answer"}}{{template "answer" .}}
+{{end}}
+<footer>
+<a href="{{.Permalink}}"><time datetime="{{.Date.Format "2006-01-02T15:04:05Z07:00"}}">{{.Date.Format "January 2, 2006"}}</time></a>
+{{range .Tags}}<a class="tag" href="{{.Url}}">#{{.Name}}</a> {{end}}
+</footer>
+</article>
+{{end}}
+
+{{define "text"}}{{with .Post.Title}}<h2>{{.}}</h2>{{end}}
+{{safe .Post.Body}}{{end}}
+
+{{define "photo"}}{{range .Post.Photos}}<figure>
+<img src="{{$.Photo .}}" alt="">
+{{with .Caption}}<figcaption>{{safe .}}</figcaption>{{end}}
+</figure>
+{{end}}{{safe .Post.Caption}}{{end}}
+
+{{define "quote"}}<blockquote>{{safe .Post.Text}}</blockquote>
+{{with .Post.Source}}<p class="source">{{safe .}}</p>{{end}}{{end}}
+
+{{define "link"}}<h2><a href="{{.Post.Url}}">{{or .Post.Title .Post.Url}}</a></h2>
+{{safe .Post.Description}}{{end}}
+
+{{define "chat"}}{{with .Post.Title}}<h2>{{.}}</h2>{{end}}
+<dl>
+{{range .Post.Dialogue}}<dt>{{.Label}}</dt><dd>{{.Phrase}}</dd>
+{{else}}<dd>{{.Post.Body}}</dd>
+{{end}}</dl>{{end}}
+
+{{define "audio"}}{{with .Post.Album_art}}<img src="{{$.Media .}}" alt="">{{end}}
+{{if .Post.Audio_url}}<audio controls src="{{.Media .Post.Audio_url}}"></audio>{{else}}{{.Player}}{{end}}
+{{with .Post.Track_name}}<p class="track">{{.}}{{with $.Post.Artist}} by {{.}}{{end}}</p>{{end}}
+{{safe .Post.Caption}}{{end}}
+
+{{define "video"}}{{if .Post.Video_url}}<video controls src="{{.Media .Post.Video_url}}"{{with .Post.Thumbnail_url}} poster="{{$.Media .}}"{{end}}></video>{{else}}{{.Player}}{{end}}
+{{safe .Post.Caption}}{{end}}
+
+{{define "answer"}}<div class="question">
+<p class="asker">{{if .Post.Asking_url}}<a href="{{.Post.Asking_url}}">{{.Post.Asking_name}}</a>{{else}}{{or .Post.Asking_name "Anonymous"}}{{end}} asked:</p>
+{{safe .Post.Question}}
+</div>
+{{safe .Post.Answer}}{{end}}
+`
+
+//Options of RenderSite.
+type SiteOptions struct {
+	//The title of the site. The title or the name of the blog of the posts if empty.
+	Title string
+	//The number of posts on an index or tag page. DefaultSitePostsPerPage if zero.
+	PostsPerPage int
+	//Templates that replace the ones of DefaultSiteTemplates with the same name,
+	//e.g. {{define "photo"}}...{{end}} to change how photo posts are rendered.
+	Templates string
+	//The path of downloaded media relative to the site, by url (see ArchiveManifest.Media).
+	//Media that isn't in it is linked to by its url.
+	Media map[string]string
+	//The width of the photos (see PhotoObject.Size). It should be the one the media was downloaded with.
+	PhotoWidth int64
+}
+
+//The data of the "page" template: an index page, a page of the posts with a tag or the page of a post.
+type SitePage struct {
+	Title string
+	//The relative path of the root of the site from the page, e.g. "../../". Empty for the pages at the root.
+	Root string
+	//The tag of the posts on a tag page.
+	Tag   string
+	Posts []SitePost
+	//The number of the page among the index or tag pages and the number of those pages.
+	//Both are 1 for the page of a post.
+	Page, Pages int
+	//The links to the pages of newer and older posts, if there are any.
+	Newer, Older string
+}
+
+//The data of the "post" template and of the templates of the types of posts.
+type SitePost struct {
+	//The post, as returned by ParsePost.
+	Post Post
+	//The type of the post, e.g. text or photo.
+	Type string
+	Date time.Time
+	//The link to the page of the post.
+	Permalink string
+	//The tags of the post and the links to their pages.
+	Tags []SiteLink
+
+	root       string
+	media      map[string]string
+	photoWidth int64
+}
+
+//A link of a site.
+type SiteLink struct {
+	Name string
+	Url  string
+}
+
+//Returns the link to the media with the given url: its downloaded file if there is one, or else the url.
+func (p SitePost) Media(mediaUrl string) string {
+	if local, ok := p.media[mediaUrl]; ok {
+		return p.root + local
+	}
+	return mediaUrl
+}
+
+//Returns the link to the photo, in the size nearest to SiteOptions.PhotoWidth.
+func (p SitePost) Photo(photo PhotoObject) string {
+	return p.Media(photo.Size(p.photoWidth).Url)
+}
+
+//Returns the embed code of the player of an audio post, or of the largest player of a video post.
+func (p SitePost) Player() template.HTML {
+	switch post := p.Post.(type) {
+	case AudioPost:
+		return template.HTML(post.Player)
+	case VideoPost:
+		var largest PlayerInfo
+		for _, player := range post.Player {
+			if player.Width >= largest.Width {
+				largest = player
+			}
+		}
+		return template.HTML(largest.Embed_code)
+	}
+	return ""
+}
+
+//Renders the posts as a static site in the directory dir, which is created if needed:
+//index.html and page/<n>/index.html list all posts newest first, tagged/<tag>/index.html and
+//tagged/<tag>/page/<n>/index.html the posts with a tag, and every post has its own page,
+//post/<id>/<slug>/index.html, after the path of its Post_url. All links between pages are relative,
+//so the site can be browsed from the file system or served from any path.
+func RenderSite(dir string, posts []Post, options SiteOptions) error {
+	templates, err := template.New("site").Funcs(template.FuncMap{
+		"safe": func(s string) template.HTML { return template.HTML(s) },
+	}).Parse(DefaultSiteTemplates)
+	if err != nil {
+		return err
+	}
+	if options.Templates != "" {
+		if templates, err = templates.Parse(options.Templates); err != nil {
+			return err
+		}
+	}
+	perPage := options.PostsPerPage
+	if perPage <= 0 {
+		perPage = DefaultSitePostsPerPage
+	}
+	posts = append([]Post{}, posts...)
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].Base().Timestamp > posts[j].Base().Timestamp
+	})
+	title := options.Title
+	for _, post := range posts {
+		if title != "" {
+			break
+		}
+		base := post.Base()
+		title = base.Blog.Title
+		if title == "" {
+			title = base.Blog_name
+		}
+	}
+
+	site := &siteRenderer{dir: dir, templates: templates, options: options, title: title}
+	if err := site.renderPages("", "", posts, perPage); err != nil {
+		return err
+	}
+	tags := map[string]string{}
+	tagged := map[string][]Post{}
+	for _, post := range posts {
+		seen := map[string]bool{}
+		for _, tag := range post.Base().Tags {
+			name := tagName(tag)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if _, ok := tags[name]; !ok {
+				tags[name] = tag
+			}
+			tagged[name] = append(tagged[name], post)
+		}
+	}
+	for name, tag := range tags {
+		if err := site.renderPages("tagged/"+name+"/", tag, tagged[name], perPage); err != nil {
+			return err
+		}
+	}
+	for _, post := range posts {
+		page := permalink(post.Base())
+		root := siteRoot(page)
+		data := SitePage{Title: title, Root: root, Posts: []SitePost{site.post(post, root)}, Page: 1, Pages: 1}
+		if err := site.render(page, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Renders the site of the published posts of the archive in archiveDir, written by ExportBlog,
+//in the directory dir, with the archived media of those posts. The media and photo width of the options are the ones of the archive.
+func RenderArchiveSite(archiveDir, dir string, options SiteOptions) error {
+	manifest, err := ReadArchiveManifest(archiveDir)
+	if err != nil {
+		return err
+	}
+	records, err := ReadArchivePosts(archiveDir, "posts")
+	if err != nil {
+		return err
+	}
+	posts := []Post{}
+	for _, record := range records {
+		post, err := record.Post()
+		if err != nil {
+			return err
+		}
+		if post.Base().State != "private" {
+			posts = append(posts, post)
+		}
+	}
+	//Only the media of the rendered posts is copied, not the one of drafts, the queue, submissions and likes.
+	media := map[string]string{}
+	copied := map[string]bool{}
+	for _, post := range posts {
+		for _, mediaUrl := range postMedia(post, manifest.PhotoWidth) {
+			local, ok := manifest.Media[mediaUrl]
+			if !ok {
+				continue
+			}
+			media[mediaUrl] = local
+			if copied[local] {
+				continue
+			}
+			copied[local] = true
+			if err := copyFile(filepath.Join(archiveDir, filepath.FromSlash(local)), filepath.Join(dir, filepath.FromSlash(local))); err != nil {
+				return err
+			}
+		}
+	}
+	options.Media = media
+	options.PhotoWidth = manifest.PhotoWidth
+	return RenderSite(dir, posts, options)
+}
+
+//Renders the pages of a site.
+type siteRenderer struct {
+	dir       string
+	templates *template.Template
+	options   SiteOptions
+	title     string
+}
+
+//Renders the posts on pages of perPage posts, the first at <prefix>index.html and the others at <prefix>page/<n>/index.html.
+func (s *siteRenderer) renderPages(prefix, tag string, posts []Post, perPage int) error {
+	pages := (len(posts) + perPage - 1) / perPage
+	if pages == 0 {
+		pages = 1
+	}
+	pagePath := func(n int) string {
+		if n == 1 {
+			return prefix + "index.html"
+		}
+		return fmt.Sprintf("%spage/%d/index.html", prefix, n)
+	}
+	for n := 1; n <= pages; n++ {
+		page := pagePath(n)
+		root := siteRoot(page)
+		data := SitePage{Title: s.title, Root: root, Tag: tag, Page: n, Pages: pages}
+		end := n * perPage
+		if end > len(posts) {
+			end = len(posts)
+		}
+		for _, post := range posts[(n-1)*perPage : end] {
+			data.Posts = append(data.Posts, s.post(post, root))
+		}
+		if n > 1 {
+			data.Newer = root + pagePath(n-1)
+		}
+		if n < pages {
+			data.Older = root + pagePath(n+1)
+		}
+		if err := s.render(page, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Returns the data of the "post" template for the post on a page whose root is root.
+func (s *siteRenderer) post(post Post, root string) SitePost {
+	base := post.Base()
+	data := SitePost{
+		Post:       post,
+		Type:       base.PostType,
+		Date:       time.Unix(base.Timestamp, 0).UTC(),
+		Permalink:  root + permalink(base),
+		Tags:       []SiteLink{},
+		root:       root,
+		media:      s.options.Media,
+		photoWidth: s.options.PhotoWidth,
+	}
+	for _, tag := range base.Tags {
+		data.Tags = append(data.Tags, SiteLink{Name: tag, Url: root + "tagged/" + tagName(tag) + "/index.html"})
+	}
+	return data
+}
+
+//Renders the "page" template with the data to the file at page, a slash separated path relative to the site.
+func (s *siteRenderer) render(page string, data SitePage) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(page))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.templates.ExecuteTemplate(file, "page", data); err != nil {
+		file.Close()
+		return fmt.Errorf("gotumblr: rendering %s: %v", page, err)
+	}
+	return file.Close()
+}
+
+//Returns the path of the page of the post relative to the site: post/<id>/<slug>/index.html
+//with the slug of its Post_url, or post/<id>/index.html if it has none.
+func permalink(post BasePost) string {
+	id := strconv.FormatInt(post.Id, 10)
+	slug := post.Slug
+	if parsed, err := url.Parse(post.Post_url); err == nil {
+		parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		if len(parts) >= 3 && parts[0] == "post" && parts[1] == id {
+			slug = parts[2]
+		}
+	}
+	if slug != "" && validSlug(slug) {
+		return "post/" + id + "/" + slug + "/index.html"
+	}
+	return "post/" + id + "/index.html"
+}
+
+//Returns the name of the directory of the pages of a tag: the tag in lower case, so that tags that differ
+//only in case share it, like on Tumblr, with dashes in place of spaces, as in the urls of Tumblr tags.
+//Other characters but letters, digits and dashes are written as _ and their hex encoded bytes,
+//so that tags like c, c# and c++ get names of their own.
+func tagName(tag string) string {
+	var name strings.Builder
+	for _, r := range tag {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			name.WriteRune(unicode.ToLower(r))
+		case r == ' ' || r == '-':
+			name.WriteByte('-')
+		default:
+			for _, b := range []byte(string(r)) {
+				fmt.Fprintf(&name, "_%02x", b)
+			}
+		}
+	}
+	return name.String()
+}
+
+//Returns the relative path of the root of the site from the page.
+func siteRoot(page string) string {
+	return strings.Repeat("../", strings.Count(page, "/"))
+}
+
+func copyFile(from, to string) error {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	target, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+	return target.Close()
+}
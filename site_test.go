@@ -0,0 +1,93 @@
+package gotumblr
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPermalink(t *testing.T) {
+	tests := []struct {
+		post BasePost
+		want string
+	}{
+		{BasePost{Id: 7161981, Post_url: "https://mgterzieva.tumblr.com/post/7161981/hello-world"}, "post/7161981/hello-world/index.html"},
+		{BasePost{Id: 7161981, Post_url: "https://mgterzieva.tumblr.com/post/7161981"}, "post/7161981/index.html"},
+		{BasePost{Id: 7161981, Slug: "from-the-slug"}, "post/7161981/from-the-slug/index.html"},
+		{BasePost{Id: 7161981, Post_url: "https://mgterzieva.tumblr.com/post/7161981/../../etc"}, "post/7161981/index.html"},
+	}
+	for _, test := range tests {
+		if got := permalink(test.post); got != test.want {
+			t.Errorf("permalink(%+v) returned %v, want %v", test.post, got, test.want)
+		}
+	}
+}
+
+func TestTagName(t *testing.T) {
+	tests := map[string]string{"Cats": "cats", "hunger games": "hunger-games", "café": "café", "!!": "_21_21", "c++": "c_2b_2b", "c#": "c_23", "c": "c"}
+	for tag, want := range tests {
+		if got := tagName(tag); got != want {
+			t.Errorf("tagName(%q) returned %q, want %q", tag, got, want)
+		}
+	}
+	names := map[string]string{}
+	for _, tag := range []string{"c", "c#", "c++", "c_23", "c--"} {
+		name := tagName(tag)
+		if other, ok := names[name]; ok {
+			t.Errorf("tagName(%q) and tagName(%q) both returned %q", tag, other, name)
+		}
+		names[name] = tag
+	}
+}
+
+func TestRenderSite(t *testing.T) {
+	dir := t.TempDir()
+	posts := []Post{
+		TextPost{BasePost: BasePost{Id: 1, PostType: "text", Timestamp: 100, Blog_name: "mgterzieva", Tags: []string{"Cats"}}, Title: "Hi", Body: "<p>Hello</p>"},
+		PhotoPost{BasePost: BasePost{Id: 2, PostType: "photo", Timestamp: 200, Post_url: "https://mgterzieva.tumblr.com/post/2/a-cat", Tags: []string{"cats"}},
+			Photos: []PhotoObject{{Alt_sizes: []AltSize{{Width: 500, Url: "http://media.tumblr.com/cat_500.jpg"}}}}},
+		ChatPost{BasePost: BasePost{Id: 3, PostType: "chat", Timestamp: 300}, Dialogue: []DialogueInfo{{Name: "Alice", Label: "Alice:", Phrase: "<Hi>"}}},
+		AnswerPost{BasePost: BasePost{Id: 4, PostType: "answer", Timestamp: 400}, Question: "Why?", Answer: "<b>Because.</b>"},
+	}
+	options := SiteOptions{PostsPerPage: 3, Media: map[string]string{"http://media.tumblr.com/cat_500.jpg": "media/2_0.jpg"}}
+	if err := RenderSite(dir, posts, options); err != nil {
+		t.Fatalf("RenderSite returned %+v, want %+v", err, nil)
+	}
+	read := func(page string) string {
+		content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(page)))
+		if err != nil {
+			t.Errorf("%v wasn't rendered: %v", page, err)
+		}
+		return string(content)
+	}
+	index := read("index.html")
+	for _, want := range []string{"<title>mgterzieva</title>", "Anonymous asked:", "<b>Because.</b>", "<dt>Alice:</dt><dd>&lt;Hi&gt;</dd>",
+		`<img src="media/2_0.jpg"`, `href="post/2/a-cat/index.html"`, `href="page/2/index.html"`} {
+		if !strings.Contains(index, want) {
+			t.Errorf("index.html doesn't contain %v:\n%v", want, index)
+		}
+	}
+	if page := read("page/2/index.html"); !strings.Contains(page, "<p>Hello</p>") || !strings.Contains(page, `href="../../index.html">Newer`) {
+		t.Errorf("page/2/index.html is:\n%v", page)
+	}
+	if page := read("post/2/a-cat/index.html"); !strings.Contains(page, `<img src="../../../media/2_0.jpg"`) || !strings.Contains(page, `href="../../../tagged/cats/index.html"`) {
+		t.Errorf("post/2/a-cat/index.html is:\n%v", page)
+	}
+	if page := read("tagged/cats/index.html"); strings.Count(page, "<article") != 2 || !strings.Contains(page, "Posts tagged #cats") {
+		t.Errorf("tagged/cats/index.html is:\n%v", page)
+	}
+	read("post/1/index.html")
+
+	options.Templates = `{{define "text"}}<p class="custom">{{.Post.Title}}</p>{{end}}`
+	if err := RenderSite(dir, posts, options); err != nil {
+		t.Fatalf("RenderSite with templates returned %+v, want %+v", err, nil)
+	}
+	if page := read("post/1/index.html"); !strings.Contains(page, `<p class="custom">Hi</p>`) || strings.Contains(page, "Hello") {
+		t.Errorf("the overridden text template rendered:\n%v", page)
+	}
+	options.Templates = `{{define "text"}}{{.Missing}}{{end}}`
+	if err := RenderSite(dir, posts, options); err == nil {
+		t.Errorf("RenderSite with a broken template returned %+v, want an error", err)
+	}
+}
@@ -0,0 +1,35 @@
+package gotumblr
+
+//A question sent to a blog through its ask box, waiting to be answered.
+type Ask struct {
+	AnswerPost
+}
+
+//Whether the question was asked anonymously.
+func (a Ask) Anonymous() bool {
+	return a.Asking_name == "" || a.Asking_name == "Anonymous"
+}
+
+//A post in a blog's submissions:
+//either a question waiting to be answered or a post submitted by another user.
+type Submission struct {
+	AnswerPost
+	//The name of the user who submitted the post.
+	Post_author   string
+	Is_submission bool
+	Title         string
+	Body          string
+	Caption       string
+	Url           string
+	Photos        []PhotoObject
+}
+
+//Whether the submission is a question.
+func (s Submission) IsAsk() bool {
+	return s.PostType == "answer"
+}
+
+//Returns the submission as a question.
+func (s Submission) Ask() Ask {
+	return Ask{s.AnswerPost}
+}
@@ -0,0 +1,100 @@
+package gotumblr
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+//Gets the posts that are waiting in the blog's submissions.
+//See Submission for the options that can be used.
+func (trc *TumblrRestClient) PendingSubmissions(blogname string, options map[string]string) []Submission {
+	submissions := []Submission{}
+	for _, raw := range trc.Submission(blogname, options).Posts {
+		var submission Submission
+		if err := json.Unmarshal(raw, &submission); err == nil {
+			submissions = append(submissions, submission)
+		}
+	}
+	return submissions
+}
+
+//Gets the questions that are waiting to be answered in the blog's submissions.
+//See Submission for the options that can be used.
+func (trc *TumblrRestClient) Asks(blogname string, options map[string]string) []Ask {
+	asks := []Ask{}
+	for _, submission := range trc.PendingSubmissions(blogname, options) {
+		if submission.IsAsk() {
+			asks = append(asks, submission.Ask())
+		}
+	}
+	return asks
+}
+
+//Answers a question and publishes the answer.
+//id: the id of the question.
+//answer: the text of the answer.
+//options can be any of the options of EditPost, e.g.:
+//state: the state of the answer post (published by default, draft, queue, private);
+//tags: a list of tags you want applied to the answer post.
+func (trc *TumblrRestClient) AnswerAsk(blogname, id, answer string, options map[string]string) (PostResult, error) {
+	params := map[string]string{"state": "published"}
+	for key, value := range options {
+		params[key] = value
+	}
+	params["id"] = id
+	params["answer"] = answer
+	return trc.EditPost(blogname, params)
+}
+
+//Publishes a submitted post.
+//id: the id of the submission.
+//options: edits to make to the post before publishing it; any of the options of EditPost.
+//state can be given to queue or draft the post instead of publishing it.
+func (trc *TumblrRestClient) PublishSubmission(blogname, id string, options map[string]string) (PostResult, error) {
+	params := map[string]string{"state": "published"}
+	for key, value := range options {
+		params[key] = value
+	}
+	params["id"] = id
+	return trc.EditPost(blogname, params)
+}
+
+//Declines a submission or question, deleting it.
+//id: the id of the submission.
+func (trc *TumblrRestClient) DeclineSubmission(blogname, id string) error {
+	return trc.DeletePost(blogname, id)
+}
+
+//Sends a question to another blog's ask box.
+//blogname: the blog you want to ask.
+//question: the text of the question.
+//anonymous: whether to ask without revealing your blog.
+func (trc *TumblrRestClient) SendAsk(blogname, question string, anonymous bool) error {
+	requestUrl := blogPath(blogname, "/ask")
+	params := map[string]string{"question": question, "anonymous": strconv.FormatBool(anonymous)}
+	data := trc.request.Post(requestUrl, params)
+	if data.Meta.Status != 200 && data.Meta.Status != 201 {
+		return errors.New(data.Meta.Msg)
+	}
+	return nil
+}
+
+//Submits a post to another blog, to be published by its owner.
+//blogname: the blog you want to submit to.
+//postType: the type of the post (text, photo, quote, link, chat, audio or video).
+//options are the ones of the Create method for the type, e.g. title and body for text posts.
+func (trc *TumblrRestClient) SubmitPost(blogname, postType string, options map[string]string) (PostResult, error) {
+	if err := ValidatePost(postType, options); err != nil {
+		return PostResult{}, err
+	}
+	requestUrl := blogPath(blogname, "/post")
+	params := map[string]string{}
+	for key, value := range options {
+		params[key] = value
+	}
+	params["type"] = postType
+	params["state"] = "submission"
+	data := trc.request.Post(requestUrl, params)
+	return postResult(data, 201)
+}
@@ -0,0 +1,92 @@
+package gotumblr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPendingSubmissions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/posts/submission", "GET", `{"response": {"posts": [
+		{"id": 2, "type": "answer", "state": "submission", "asking_name": "Anonymous", "question": "Which district?"},
+		{"id": 1, "type": "text", "state": "submission", "post_author": "thehungergamesmovie", "is_submission": true, "title": "Fan art", "body": "Look!"}]}}`,
+		map[string]string{}, t)
+
+	submissions := client.PendingSubmissions("mgterzieva", map[string]string{})
+	if len(submissions) != 2 {
+		t.Fatalf("PendingSubmissions returned %v submissions, want %v", len(submissions), 2)
+	}
+	if !submissions[0].IsAsk() || submissions[1].IsAsk() {
+		t.Errorf("IsAsk returned %v and %v, want true and false", submissions[0].IsAsk(), submissions[1].IsAsk())
+	}
+	if submissions[1].Post_author != "thehungergamesmovie" || !submissions[1].Is_submission || submissions[1].Body != "Look!" {
+		t.Errorf("PendingSubmissions returned %+v", submissions[1])
+	}
+
+	asks := client.Asks("mgterzieva", map[string]string{})
+	if len(asks) != 1 || asks[0].Question != "Which district?" || asks[0].Id != 2 || !asks[0].Anonymous() {
+		t.Errorf("Asks returned %+v", asks)
+	}
+}
+
+func TestAnswerAsk(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", `{"meta": {"status": 200, "msg": "OK"}, "response": {"id": 2}}`, map[string]string{"id": "2", "answer": "District 12", "state": "published", "tags": "asks"}, t)
+
+	result, err := client.AnswerAsk("mgterzieva", "2", "District 12", map[string]string{"tags": "asks"})
+	if err != nil || result.ID != "2" {
+		t.Errorf("AnswerAsk returned %+v, %+v, want the post 2", result, err)
+	}
+}
+
+func TestPublishSubmission(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "1", "state": "queue", "title": "Fan art!"}, t)
+
+	if _, err := client.PublishSubmission("mgterzieva", "1", map[string]string{"state": "queue", "title": "Fan art!"}); err != nil {
+		t.Errorf("PublishSubmission returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestDeclineSubmission(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/delete", "POST", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"id": "1"}, t)
+
+	if err := client.DeclineSubmission("mgterzieva", "1"); err != nil {
+		t.Errorf("DeclineSubmission returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestSendAsk(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/thehungergamesmovie/ask", "POST", `{"meta": {"status": 400, "msg": "Asks are disabled"}}`, map[string]string{"question": "When is the premiere?", "anonymous": "true"}, t)
+
+	err := client.SendAsk("thehungergamesmovie", "When is the premiere?", true)
+	if !reflect.DeepEqual(err, errors.New("Asks are disabled")) {
+		t.Errorf("SendAsk returned %+v, want Asks are disabled", err)
+	}
+}
+
+func TestSubmitPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/thehungergamesmovie/post", "POST", `{"meta": {"status": 201, "msg": "Created"}, "response": {"id": "72078164824", "state": "submission"}}`, map[string]string{"type": "text", "state": "submission", "body": "Fan art"}, t)
+
+	result, err := client.SubmitPost("thehungergamesmovie", "text", map[string]string{"body": "Fan art", "state": "published"})
+	want := PostResult{ID: "72078164824", State: "submission"}
+	if err != nil || result != want {
+		t.Errorf("SubmitPost returned %+v, %+v, want %+v", result, err, want)
+	}
+}
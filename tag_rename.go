@@ -0,0 +1,158 @@
+package gotumblr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//Renames tags on all published, queued and draft posts of the blog that have them.
+//renames maps old tag names to new ones, ignoring case, so no two old names may differ only by case; renaming several tags to the same name merges them
+//and a post that ends up with a tag twice keeps it once. Only the tags of the posts are edited.
+//Set options.UndoLog to be able to revert the renaming with UndoBulk.
+func (trc *TumblrRestClient) RenameTags(ctx context.Context, blogname string, renames map[string]string, options BulkOptions) (*BulkReport, error) {
+	return renameTags(ctx, trc, blogname, renames, options)
+}
+
+//See RenameTags.
+func renameTags(ctx context.Context, client Client, blogname string, renames map[string]string, options BulkOptions) (*BulkReport, error) {
+	if len(renames) == 0 {
+		return nil, errors.New("gotumblr: no tags to rename")
+	}
+	if err := checkRenames(renames); err != nil {
+		return nil, err
+	}
+	selector := PostSelector{}
+	if len(renames) == 1 {
+		for old := range renames {
+			selector.Tag = old
+		}
+	}
+	selected, err := selectPosts(ctx, client, blogname, selector)
+	if err != nil {
+		return nil, err
+	}
+	posts := []BasePost{}
+	for _, post := range selected {
+		for old := range renames {
+			if containsTag(post.Tags, old) {
+				posts = append(posts, post)
+				break
+			}
+		}
+	}
+	action := BulkAction{RenameTags: renames}
+	report := &BulkReport{Blog: blogname, Action: action, DryRun: options.DryRun}
+	return report, runBulk(ctx, client, blogname, report, posts, false, action.edit, options)
+}
+
+//An edit made by a bulk operation, as written to BulkOptions.UndoLog.
+//Entries are written before their edit is made, so the log can list an edit that failed;
+//reverting it with UndoBulk is harmless, since the post still has its old tags and state.
+type BulkUndoEntry struct {
+	ID string `json:"id"`
+	//The tags of the post before and after the edit.
+	OldTags []string `json:"old_tags"`
+	NewTags []string `json:"new_tags"`
+	//The state of the post before and after the edit (e.g. published or queued).
+	OldState string `json:"old_state"`
+	NewState string `json:"new_state"`
+}
+
+//Writes the undo entry of an edit of the post as a line of JSON and syncs w if it can be synced.
+func writeUndoEntry(w io.Writer, post BasePost, changes map[string]string) error {
+	entry := BulkUndoEntry{
+		ID:       strconv.FormatInt(post.Id, 10),
+		OldTags:  post.Tags,
+		NewTags:  post.Tags,
+		OldState: post.State,
+		NewState: post.State,
+	}
+	if entry.OldTags == nil {
+		entry.OldTags = []string{}
+		entry.NewTags = []string{}
+	}
+	if tags, ok := changes["tags"]; ok {
+		entry.NewTags = []string{}
+		for _, tag := range strings.Split(tags, ",") {
+			if tag != "" {
+				entry.NewTags = append(entry.NewTags, tag)
+			}
+		}
+	}
+	if state, ok := changes["state"]; ok {
+		entry.NewState = state
+		if state == "queue" {
+			entry.NewState = "queued"
+		}
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if syncer, ok := w.(interface{ Sync() error }); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
+
+//Reverts the edits recorded in an undo log written by a bulk operation or RenameTags.
+//Each post gets back the tags and the state it had before its first recorded edit.
+//The options are the ones of Bulk; an UndoLog set in them records the undo itself.
+func (trc *TumblrRestClient) UndoBulk(ctx context.Context, blogname string, undoLog io.Reader, options BulkOptions) (*BulkReport, error) {
+	return undoBulk(ctx, trc, blogname, undoLog, options)
+}
+
+//See UndoBulk.
+func undoBulk(ctx context.Context, client Client, blogname string, undoLog io.Reader, options BulkOptions) (*BulkReport, error) {
+	original := map[int64]BulkUndoEntry{}
+	current := map[int64]BulkUndoEntry{}
+	order := []int64{}
+	decoder := json.NewDecoder(undoLog)
+	for {
+		var entry BulkUndoEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		id, err := strconv.ParseInt(entry.ID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := original[id]; !ok {
+			original[id] = entry
+			order = append(order, id)
+		}
+		current[id] = entry
+	}
+	posts := make([]BasePost, len(order))
+	for i, id := range order {
+		posts[i] = BasePost{Id: id, Tags: current[id].NewTags, State: current[id].NewState}
+	}
+	revert := func(post BasePost) map[string]string {
+		entry := original[post.Id]
+		changes := map[string]string{"id": entry.ID}
+		if !sameTags(entry.OldTags, post.Tags) {
+			changes["tags"] = strings.Join(entry.OldTags, ",")
+		}
+		if entry.OldState != post.State {
+			changes["state"] = entry.OldState
+			if entry.OldState == "queued" {
+				changes["state"] = "queue"
+			}
+		}
+		if len(changes) == 1 {
+			return nil
+		}
+		return changes
+	}
+	report := &BulkReport{Blog: blogname, DryRun: options.DryRun}
+	return report, runBulk(ctx, client, blogname, report, posts, false, revert, options)
+}
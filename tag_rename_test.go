@@ -0,0 +1,113 @@
+package gotumblr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestBulkActionRenameTags(t *testing.T) {
+	action := BulkAction{RenameTags: map[string]string{"colour": "color", "Color": "color", "wip": "work-in-progress"}}
+	tests := []struct {
+		tags []string
+		want map[string]string
+	}{
+		{[]string{"WIP", "cats"}, map[string]string{"id": "7", "tags": "work-in-progress,cats"}},
+		{[]string{"colour", "Color", "cats"}, map[string]string{"id": "7", "tags": "color,cats"}},
+		{[]string{"cats"}, nil},
+	}
+	for _, test := range tests {
+		if got := action.edit(BasePost{Id: 7, Tags: test.tags}); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("edit of %v returned %v, want %v", test.tags, got, test.want)
+		}
+	}
+}
+
+func TestWriteUndoEntry(t *testing.T) {
+	var log bytes.Buffer
+	post := BasePost{Id: 7, Tags: []string{"wip"}, State: "draft"}
+	if err := writeUndoEntry(&log, post, map[string]string{"id": "7", "tags": "", "state": "queue"}); err != nil {
+		t.Fatalf("writeUndoEntry returned %+v, want %+v", err, nil)
+	}
+	want := `{"id":"7","old_tags":["wip"],"new_tags":[],"old_state":"draft","new_state":"queued"}` + "\n"
+	if log.String() != want {
+		t.Errorf("writeUndoEntry wrote %v, want %v", log.String(), want)
+	}
+}
+
+//An undo log that counts how many times it was synced.
+type syncedLog struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+	synced int
+}
+
+func (l *syncedLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buffer.Write(p)
+}
+
+func (l *syncedLog) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.synced++
+	return nil
+}
+
+func TestUndoEntryWrittenBeforeEdit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	log := &syncedLog{}
+	mux.HandleFunc("/v2/blog/mgterzieva/post/edit", func(w http.ResponseWriter, r *http.Request) {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+		if log.synced != 1 || log.buffer.Len() == 0 {
+			t.Errorf("the post was edited before its undo entry was written and synced")
+		}
+		fmt.Fprint(w, `{"meta": {"status": 500, "msg": "Internal Server Error"}}`)
+	})
+
+	action := BulkAction{RenameTags: map[string]string{"wip": "work"}}
+	report := &BulkReport{}
+	posts := []BasePost{{Id: 7, Tags: []string{"wip"}, State: "draft"}}
+	runBulk(context.Background(), client, "mgterzieva", report, posts, false, action.edit, BulkOptions{UndoLog: log})
+	if report.Items[0].Status != BulkFailed {
+		t.Errorf("the edit has the status %v, want %v", report.Items[0].Status, BulkFailed)
+	}
+	want := `{"id":"7","old_tags":["wip"],"new_tags":["work"],"old_state":"draft","new_state":"draft"}` + "\n"
+	if log.buffer.String() != want {
+		t.Errorf("the undo log is %v, want %v", log.buffer.String(), want)
+	}
+}
+
+func TestRenameTagsWithoutRenames(t *testing.T) {
+	if _, err := client.RenameTags(context.Background(), "mgterzieva", map[string]string{}, BulkOptions{}); err == nil {
+		t.Errorf("RenameTags returned %+v, want an error", err)
+	}
+}
+
+func TestUndoBulk(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post/edit", "POST", `{"meta": {"status": 200, "msg": "OK"}, "response": {"id": 7}}`, map[string]string{"id": "7", "tags": "wip", "state": "draft"}, t)
+
+	log := bytes.NewBufferString(`{"id":"7","old_tags":["wip"],"new_tags":["work"],"old_state":"draft","new_state":"draft"}
+{"id":"7","old_tags":["work"],"new_tags":["done"],"old_state":"draft","new_state":"queued"}
+`)
+	report, err := client.UndoBulk(context.Background(), "mgterzieva", log, BulkOptions{})
+	if err != nil || len(report.Items) != 1 || report.Items[0].Status != BulkSucceeded {
+		t.Errorf("UndoBulk returned %+v, %+v", report, err)
+	}
+
+	_, err = client.UndoBulk(context.Background(), "mgterzieva", bytes.NewBufferString("{"), BulkOptions{})
+	if err == nil {
+		t.Errorf("UndoBulk of a broken log returned %+v, want an error", err)
+	}
+}
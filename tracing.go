@@ -0,0 +1,53 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+//Tracer starts a span for every API call made through a TumblrRequest.
+//Its shape follows OpenTelemetry's, so that an adapter to an OpenTelemetry tracer is a few lines long.
+type Tracer interface {
+	//Starts a span with the given name and attributes as a child of the span in ctx, if any.
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+//Span is a traced API call.
+type Span interface {
+	//Adds attributes to the span.
+	SetAttributes(attributes map[string]string)
+	//Records an error that made the call fail.
+	RecordError(err error)
+	//Ends the span.
+	End()
+}
+
+//Returns middleware that emits a span for every API call through tracer.
+//Spans are named after the method and the endpoint (e.g. GET /v2/blog/{blog}/info)
+//and have the tumblr.endpoint, tumblr.blog, http.method and http.status_code attributes.
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			attributes := map[string]string{
+				"http.method":     call.Method,
+				"tumblr.endpoint": call.Name,
+			}
+			if call.Blog != "" {
+				attributes["tumblr.blog"] = call.Blog
+			}
+			ctx, span := tracer.Start(call.Context, call.Method+" "+call.Name, attributes)
+			defer span.End()
+			call.Context = ctx
+			response := next(call)
+			status := callStatus(call)
+			span.SetAttributes(map[string]string{"http.status_code": strconv.Itoa(status)})
+			if call.Err != nil {
+				span.RecordError(call.Err)
+			} else if status >= 400 {
+				span.RecordError(errors.New(response.Meta.Msg))
+			}
+			return response
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package gotumblr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type testSpan struct {
+	name       string
+	attributes map[string]string
+	errors     []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttributes(attributes map[string]string) {
+	for key, value := range attributes {
+		s.attributes[key] = value
+	}
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/post", "POST", `{"meta": {"status": 400, "msg": "Bad Request"}}`, map[string]string{"body": "Hello"}, t)
+
+	tracer := &testTracer{}
+	client.Use(TracingMiddleware(tracer))
+	client.CreateText("mgterzieva.tumblr.com", map[string]string{"body": "Hello"})
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("tracer started %v spans, want 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	want := map[string]string{
+		"http.method":      "POST",
+		"http.status_code": "400",
+		"tumblr.endpoint":  "/v2/blog/{blog}/post",
+		"tumblr.blog":      "mgterzieva",
+	}
+	if span.name != "POST /v2/blog/{blog}/post" || !reflect.DeepEqual(span.attributes, want) {
+		t.Errorf("span = %v %v, want POST /v2/blog/{blog}/post %v", span.name, span.attributes, want)
+	}
+	if len(span.errors) != 1 || span.errors[0].Error() != "Bad Request" || !span.ended {
+		t.Errorf("span errors = %v, ended = %v, want Bad Request and ended", span.errors, span.ended)
+	}
+}
+
+func TestTracingMiddlewareContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/blog/mgterzieva/info", "GET", `{"response": {"blog": {}}}`, map[string]string{}, t)
+
+	tracer := &testTracer{}
+	var inner interface{}
+	client.Use(TracingMiddleware(tracer), func(next Handler) Handler {
+		return func(call *APICall) CompleteResponse {
+			inner = call.Context.Value(testSpanKey{})
+			return next(call)
+		}
+	})
+	client.BlogInfo("mgterzieva")
+
+	if len(tracer.spans) != 1 || inner != tracer.spans[0] {
+		t.Errorf("the middleware after TracingMiddleware got the span %v, want the span of the call", inner)
+	}
+}
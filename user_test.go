@@ -0,0 +1,116 @@
+package gotumblr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUserLimits(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/limits", "GET", `{"response": {"user": {
+		"posts": {"description": "Number of posts you can create per day", "limit": 250, "remaining": 249, "reset_at": 1414713600},
+		"follows": {"limit": 200, "remaining": 200, "reset_at": 1414713600}}}}`, map[string]string{}, t)
+
+	limits := client.UserLimits().User
+	want := Limit{Description: "Number of posts you can create per day", Limit: 250, Remaining: 249, Reset_at: 1414713600}
+	if !reflect.DeepEqual(limits.Posts, want) {
+		t.Errorf("UserLimits returned %+v, want %+v", limits.Posts, want)
+	}
+	if limits.Follows.Remaining != 200 {
+		t.Errorf("UserLimits returned %v remaining follows, want %v", limits.Follows.Remaining, 200)
+	}
+	if reset := limits.Posts.ResetTime(); !reset.Equal(time.Unix(1414713600, 0)) {
+		t.Errorf("ResetTime returned %v", reset)
+	}
+}
+
+func TestFilteredTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/filtered_tags", "GET", `{"response": {"filtered_tags": ["spoilers", "the hunger games"]}}`, map[string]string{}, t)
+
+	tags := client.FilteredTags().Filtered_tags
+	want := []string{"spoilers", "the hunger games"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("FilteredTags returned %+v, want %+v", tags, want)
+	}
+}
+
+func TestAddFilteredTags(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/filtered_tags", "POST", `{"meta": {"status": 201, "msg": "Created"}}`, map[string]string{"filtered_tags[0]": "spoilers", "filtered_tags[1]": "the hunger games, part 2"}, t)
+
+	if err := client.AddFilteredTags("spoilers", "the hunger games, part 2"); err != nil {
+		t.Errorf("AddFilteredTags returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestRemoveFilteredTag(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/user/filtered_tags/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Request method = %v, want DELETE", r.Method)
+		}
+		if r.URL.Path != "/v2/user/filtered_tags/the hunger games" {
+			fmt.Fprint(w, `{"meta": {"status": 404, "msg": "Not Found"}}`)
+			return
+		}
+		if r.URL.EscapedPath() != "/v2/user/filtered_tags/the%20hunger%20games" {
+			t.Errorf("the tag was sent as %v, want it escaped", r.URL.EscapedPath())
+		}
+		fmt.Fprint(w, `{"meta": {"status": 200, "msg": "OK"}}`)
+	})
+
+	if err := client.RemoveFilteredTag("the hunger games"); err != nil {
+		t.Errorf("RemoveFilteredTag returned %+v, want %+v", err, nil)
+	}
+	err := client.RemoveFilteredTag("spoilers")
+	if !reflect.DeepEqual(err, errors.New("Not Found")) {
+		t.Errorf("RemoveFilteredTag returned %+v, want Not Found", err)
+	}
+}
+
+func TestFilteredContent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/filtered_content", "GET", `{"response": {"filtered_content": ["mockingjay"]}}`, map[string]string{}, t)
+
+	content := client.FilteredContent().Filtered_content
+	if !reflect.DeepEqual(content, []string{"mockingjay"}) {
+		t.Errorf("FilteredContent returned %+v, want %+v", content, []string{"mockingjay"})
+	}
+}
+
+func TestAddFilteredContent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/filtered_content", "POST", `{"meta": {"status": 201, "msg": "Created"}}`, map[string]string{"filtered_content[0]": "mockingjay", "filtered_content[1]": "district 12"}, t)
+
+	if err := client.AddFilteredContent("mockingjay", "district 12"); err != nil {
+		t.Errorf("AddFilteredContent returned %+v, want %+v", err, nil)
+	}
+}
+
+func TestRemoveFilteredContent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	handleFunc("/v2/user/filtered_content", "DELETE", `{"meta": {"status": 200, "msg": "OK"}}`, map[string]string{"filtered_content": "mockingjay"}, t)
+
+	if err := client.RemoveFilteredContent("mockingjay"); err != nil {
+		t.Errorf("RemoveFilteredContent returned %+v, want %+v", err, nil)
+	}
+}
@@ -0,0 +1,28 @@
+package gotumblr
+
+import "time"
+
+//The daily limits of the user.
+type UserLimits struct {
+	Blogs         Limit
+	Follows       Limit
+	Likes         Limit
+	Photos        Limit
+	Posts         Limit
+	Videos        Limit
+	Video_seconds Limit
+}
+
+//A limit on how many times an action can be done before it resets.
+type Limit struct {
+	Description string
+	Limit       int64
+	Remaining   int64
+	//The unix timestamp of when Remaining is reset to Limit.
+	Reset_at int64
+}
+
+//Returns the time when the limit resets.
+func (l Limit) ResetTime() time.Time {
+	return time.Unix(l.Reset_at, 0)
+}
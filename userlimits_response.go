@@ -0,0 +1,5 @@
+package gotumblr
+
+type UserLimitsResponse struct {
+	User UserLimits
+}
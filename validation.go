@@ -0,0 +1,181 @@
+package gotumblr
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+//The most tags a post can have.
+const MaxTags = 30
+
+//The most characters a tag can have.
+const MaxTagLength = 140
+
+//The formats accepted for the date option of posts.
+var DateFormats = []string{
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+//An invalid parameter of a request.
+type FieldError struct {
+	//The name of the parameter (e.g. body).
+	Field string
+	//What is wrong with it (e.g. is required).
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+//The errors found by validating the parameters of a request before sending it.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+	return "gotumblr: invalid parameters: " + strings.Join(messages, "; ")
+}
+
+//Adds a field error.
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{field, message})
+}
+
+//Returns the error, or nil if no field errors were found.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+//The options each type of post requires. When several are listed, exactly one of them is required.
+var requiredOptions = map[string][]string{
+	"text":  {"body"},
+	"photo": {"source", "data"},
+	"quote": {"quote"},
+	"link":  {"url"},
+	"chat":  {"conversation"},
+	"audio": {"external_url", "data"},
+	"video": {"embed", "data"},
+}
+
+//Checks the options of a Create* call for the given type of post.
+//It returns a *ValidationError with all the problems found, or nil if there are none.
+func ValidatePost(postType string, options map[string]string) error {
+	errs := &ValidationError{}
+	required, ok := requiredOptions[postType]
+	if !ok {
+		errs.add("type", "must be one of text, photo, quote, link, chat, audio or video")
+	}
+	given := []string{}
+	for _, option := range required {
+		if hasOption(options, option) {
+			given = append(given, option)
+		}
+	}
+	switch {
+	case len(given) > 1:
+		errs.add(given[1], "cannot be used together with "+given[0])
+	case len(given) == 0 && len(required) == 1:
+		errs.add(required[0], "is required")
+	case len(given) == 0 && len(required) > 1:
+		errs.add(required[0], "or "+strings.Join(required[1:], " or ")+" is required")
+	}
+	validateOptions(errs, options)
+	return errs.err()
+}
+
+//Checks the options of an EditPost call.
+//It returns a *ValidationError with all the problems found, or nil if there are none.
+func ValidateEdit(options map[string]string) error {
+	errs := &ValidationError{}
+	if options["id"] == "" {
+		errs.add("id", "is required")
+	}
+	if hasOption(options, "source") && hasOption(options, "data") {
+		errs.add("data", "cannot be used together with source")
+	}
+	validateOptions(errs, options)
+	return errs.err()
+}
+
+//Checks the options of a Reblog call.
+//It returns a *ValidationError with all the problems found, or nil if there are none.
+func ValidateReblog(options map[string]string) error {
+	errs := &ValidationError{}
+	for _, option := range []string{"id", "reblog_key"} {
+		if options[option] == "" {
+			errs.add(option, "is required")
+		}
+	}
+	validateOptions(errs, options)
+	return errs.err()
+}
+
+//Checks the options common to all posts.
+func validateOptions(errs *ValidationError, options map[string]string) {
+	if tags, ok := options["tags"]; ok {
+		validateTags(errs, strings.Split(tags, ","))
+	}
+	if date, ok := options["date"]; ok && !validDate(date) {
+		errs.add("date", "must be a date such as 2014-02-14 18:30:00 GMT")
+	}
+	if publishOn, ok := options["publish_on"]; ok {
+		if _, err := time.Parse(time.RFC3339, publishOn); err != nil {
+			errs.add("publish_on", "must be an RFC 3339 date such as 2014-02-14T18:30:00Z")
+		}
+	}
+	if slug, ok := options["slug"]; ok && !validSlug(slug) {
+		errs.add("slug", "can only contain letters, digits, hyphens and underscores")
+	}
+}
+
+//Checks the number and the length of tags.
+func validateTags(errs *ValidationError, tags []string) {
+	if len(tags) > MaxTags {
+		errs.add("tags", "cannot be more than "+strconv.Itoa(MaxTags))
+	}
+	for _, tag := range tags {
+		if len([]rune(strings.TrimSpace(tag))) > MaxTagLength {
+			errs.add("tags", "cannot be longer than "+strconv.Itoa(MaxTagLength)+" characters: "+tag)
+		}
+	}
+}
+
+//Reports whether the option, or an array option such as data[0], is set.
+func hasOption(options map[string]string, name string) bool {
+	for key, value := range options {
+		if (key == name || strings.HasPrefix(key, name+"[")) && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func validDate(date string) bool {
+	for _, format := range DateFormats {
+		if _, err := time.Parse(format, date); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func validSlug(slug string) bool {
+	for _, c := range slug {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
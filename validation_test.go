@@ -0,0 +1,127 @@
+package gotumblr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidatePost(t *testing.T) {
+	tests := []struct {
+		postType string
+		options  map[string]string
+		want     []FieldError
+	}{
+		{"text", map[string]string{"body": "Hello"}, nil},
+		{"text", map[string]string{}, []FieldError{{"body", "is required"}}},
+		{"photo", map[string]string{"data[0]": "..."}, nil},
+		{"photo", map[string]string{}, []FieldError{{"source", "or data is required"}}},
+		{"photo", map[string]string{"source": "http://pics.com/cat.jpg", "data": "..."}, []FieldError{{"data", "cannot be used together with source"}}},
+		{"quote", map[string]string{"quote": "Hi", "source": "Ziggy"}, nil},
+		{"link", map[string]string{"url": ""}, []FieldError{{"url", "is required"}}},
+		{"chat", map[string]string{"conversation": "Alice: Hi!"}, nil},
+		{"audio", map[string]string{"external_url": "http://coolsongs.com/song"}, nil},
+		{"video", map[string]string{"embed": "<iframe></iframe>", "data": "..."}, []FieldError{{"data", "cannot be used together with embed"}}},
+		{"answer", map[string]string{}, []FieldError{{"type", "must be one of text, photo, quote, link, chat, audio or video"}}},
+		{"text", map[string]string{"body": "Hello", "tags": tooManyTags()}, []FieldError{{"tags", "cannot be more than 30"}}},
+		{"text", map[string]string{"body": "Hello", "tags": "cats," + strings.Repeat("a", 141)},
+			[]FieldError{{"tags", "cannot be longer than 140 characters: " + strings.Repeat("a", 141)}}},
+		{"text", map[string]string{"body": "Hello", "date": "2014-02-14 18:30:00 GMT", "slug": "hello-world_2"}, nil},
+		{"text", map[string]string{"body": "Hello", "date": "yesterday"}, []FieldError{{"date", "must be a date such as 2014-02-14 18:30:00 GMT"}}},
+		{"text", map[string]string{"body": "Hello", "publish_on": "2014-02-14"}, []FieldError{{"publish_on", "must be an RFC 3339 date such as 2014-02-14T18:30:00Z"}}},
+		{"text", map[string]string{"body": "Hello", "slug": "hello world"}, []FieldError{{"slug", "can only contain letters, digits, hyphens and underscores"}}},
+		{"text", map[string]string{"date": "yesterday", "slug": "hello/world"}, []FieldError{
+			{"body", "is required"},
+			{"date", "must be a date such as 2014-02-14 18:30:00 GMT"},
+			{"slug", "can only contain letters, digits, hyphens and underscores"},
+		}},
+	}
+	for _, test := range tests {
+		err := ValidatePost(test.postType, test.options)
+		if test.want == nil {
+			if err != nil {
+				t.Errorf("ValidatePost(%v, %v) returned %v, want nil", test.postType, test.options, err)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || !reflect.DeepEqual(validationErr.Fields, test.want) {
+			t.Errorf("ValidatePost(%v, %v) returned %+v, want %+v", test.postType, test.options, err, test.want)
+		}
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := ValidatePost("text", map[string]string{"slug": "a b"})
+	want := "gotumblr: invalid parameters: body is required; slug can only contain letters, digits, hyphens and underscores"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidatePost returned %v, want %v", err, want)
+	}
+}
+
+func TestValidateEdit(t *testing.T) {
+	if err := ValidateEdit(map[string]string{"id": "7161981", "tags": "cats,dogs"}); err != nil {
+		t.Errorf("ValidateEdit returned %v, want nil", err)
+	}
+	err := ValidateEdit(map[string]string{"source": "http://pics.com/cat.jpg", "data": "..."})
+	want := &ValidationError{[]FieldError{{"id", "is required"}, {"data", "cannot be used together with source"}}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("ValidateEdit returned %+v, want %+v", err, want)
+	}
+}
+
+func TestValidateReblog(t *testing.T) {
+	err := ValidateReblog(map[string]string{"tags": "cats"})
+	want := &ValidationError{[]FieldError{{"id", "is required"}, {"reblog_key", "is required"}}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("ValidateReblog returned %+v, want %+v", err, want)
+	}
+}
+
+func TestCreateValidatesBeforeSending(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request sent to %v, want no request", r.URL.Path)
+	})
+
+	creates := map[string]func(string, map[string]string) (PostResult, error){
+		"CreateText":     client.CreateText,
+		"CreatePhoto":    client.CreatePhoto,
+		"CreateQuote":    client.CreateQuote,
+		"CreateLink":     client.CreateLink,
+		"CreateChatPost": client.CreateChatPost,
+		"CreateAudio":    client.CreateAudio,
+		"CreateVideo":    client.CreateVideo,
+		"EditPost":       client.EditPost,
+	}
+	for name, create := range creates {
+		_, err := create("mgterzieva", map[string]string{"tags": tooManyTags()})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || len(validationErr.Fields) != 2 {
+			t.Errorf("%v returned %+v, want two field errors", name, err)
+		}
+	}
+
+	if _, err := client.Reblog("mgterzieva", map[string]string{"id": "7161981"}); !reflect.DeepEqual(err, &ValidationError{[]FieldError{{"reblog_key", "is required"}}}) {
+		t.Errorf("Reblog returned %+v, want reblog_key is required", err)
+	}
+	if _, err := client.SubmitPost("thehungergamesmovie", "text", map[string]string{"tags": tooManyTags()}); !errors.As(err, new(*ValidationError)) {
+		t.Errorf("SubmitPost returned %+v, want a validation error", err)
+	}
+
+	sourcePost := BasePost{Id: 7161981, Reblog_key: "blah", Blog_name: "staff"}
+	_, err := client.ReblogPost(context.Background(), "mgterzieva", sourcePost, ReblogOptions{Tags: []string{strings.Repeat("a", 141)}})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("ReblogPost returned %+v, want a validation error", err)
+	}
+}
+
+func tooManyTags() string {
+	return strings.Repeat("tag,", MaxTags) + "tag"
+}